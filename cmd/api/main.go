@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	_ "time/tzdata" // working hours need time zones; the runtime image ships none
 
 	"github.com/V1merX/pr-reviewer-service/internal/app"
 )
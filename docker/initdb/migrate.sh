@@ -0,0 +1,9 @@
+#!/bin/sh
+# Applies the up migrations in order on the first start of the database
+# container. The down files live next to them and must not run here.
+set -e
+
+for migration in /migrations/*.up.sql; do
+	echo "applying $migration"
+	psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" -f "$migration"
+done
@@ -7,28 +7,218 @@ import (
 	"time"
 )
 
+// Defines values for AssignmentTraceAction.
+const (
+	AssignmentTraceActionCreate         AssignmentTraceAction = "create"
+	AssignmentTraceActionTopUp          AssignmentTraceAction = "top_up"
+	AssignmentTraceActionMarkReady      AssignmentTraceAction = "mark_ready"
+	AssignmentTraceActionReassign       AssignmentTraceAction = "reassign"
+	AssignmentTraceActionAddReviewer    AssignmentTraceAction = "add_reviewer"
+	AssignmentTraceActionRemoveReviewer AssignmentTraceAction = "remove_reviewer"
+	AssignmentTraceActionRebalance      AssignmentTraceAction = "rebalance"
+)
+
+// Defines values for AssignmentTraceStage.
+const (
+	AssignmentTraceStageCodeowners  AssignmentTraceStage = "codeowners"
+	AssignmentTraceStageFill        AssignmentTraceStage = "fill"
+	AssignmentTraceStageReplacement AssignmentTraceStage = "replacement"
+	AssignmentTraceStageRequested   AssignmentTraceStage = "requested"
+	AssignmentTraceStageSenior      AssignmentTraceStage = "senior"
+	AssignmentTraceStageSkills      AssignmentTraceStage = "skills"
+)
+
+// Defines values for CandidateExclusion.
+const (
+	CandidateExclusionAlreadyAssigned CandidateExclusion = "already_assigned"
+	CandidateExclusionAtCapacity      CandidateExclusion = "at_capacity"
+	CandidateExclusionAuthor          CandidateExclusion = "author"
+	CandidateExclusionExcluded        CandidateExclusion = "excluded"
+	CandidateExclusionInactive        CandidateExclusion = "inactive"
+	CandidateExclusionNotRequested    CandidateExclusion = "not_requested"
+	CandidateExclusionNotSenior       CandidateExclusion = "not_senior"
+	CandidateExclusionUnavailable     CandidateExclusion = "unavailable"
+	CandidateExclusionUnknownUser     CandidateExclusion = "unknown_user"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	ALREADYASSIGNED   ErrorResponseErrorCode = "ALREADY_ASSIGNED"
+	CAPACITYEXHAUSTED ErrorResponseErrorCode = "CAPACITY_EXHAUSTED"
+	INVALIDCODEOWNERS ErrorResponseErrorCode = "INVALID_CODEOWNERS"
+	INVALIDREVIEWER   ErrorResponseErrorCode = "INVALID_REVIEWER"
+	NOTELIGIBLE       ErrorResponseErrorCode = "NOT_ELIGIBLE"
+	NOCANDIDATE       ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED       ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND          ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS          ErrorResponseErrorCode = "PR_EXISTS"
+	NOSENIOR          ErrorResponseErrorCode = "NO_SENIOR"
+	INVALIDTRANSITION ErrorResponseErrorCode = "INVALID_TRANSITION"
+	MERGEBLOCKED      ErrorResponseErrorCode = "MERGE_BLOCKED"
+	PRMERGED          ErrorResponseErrorCode = "PR_MERGED"
+	PRCHANGED         ErrorResponseErrorCode = "PR_CHANGED"
+	LASTREVIEWER      ErrorResponseErrorCode = "LAST_REVIEWER"
+	FORBIDDEN         ErrorResponseErrorCode = "FORBIDDEN"
+	PRNOTOPEN         ErrorResponseErrorCode = "PR_NOT_OPEN"
+	SKILLSNOTCOVERED  ErrorResponseErrorCode = "SKILLS_NOT_COVERED"
+	TEAMEXISTS        ErrorResponseErrorCode = "TEAM_EXISTS"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for PullRequestSort.
+const (
+	PullRequestSortCreatedAt PullRequestSort = "created_at"
+	PullRequestSortMergedAt  PullRequestSort = "merged_at"
+	PullRequestSortSize      PullRequestSort = "size"
+)
+
+// Defines values for Seniority.
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityLead   Seniority = "lead"
+	SeniorityMid    Seniority = "mid"
+	SenioritySenior Seniority = "senior"
+)
+
+// Defines values for SortOrder.
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// Defines values for SkillMatchMode.
+const (
+	SkillMatchModePrefer  SkillMatchMode = "prefer"
+	SkillMatchModeRequire SkillMatchMode = "require"
+)
+
+// Defines values for ReviewVerdict.
+const (
+	ReviewVerdictAPPROVED         ReviewVerdict = "APPROVED"
+	ReviewVerdictCHANGESREQUESTED ReviewVerdict = "CHANGES_REQUESTED"
+	ReviewVerdictCOMMENTED        ReviewVerdict = "COMMENTED"
+)
+
+// Defines values for ReviewerSource.
+const (
+	ReviewerSourceAuto      ReviewerSource = "auto"
+	ReviewerSourceRequested ReviewerSource = "requested"
+)
+
+// Defines values for TeamAssignmentStrategy.
+const (
+	TeamAssignmentStrategyLeastLoaded    TeamAssignmentStrategy = "least_loaded"
+	TeamAssignmentStrategyRandom         TeamAssignmentStrategy = "random"
+	TeamAssignmentStrategyRoundRobin     TeamAssignmentStrategy = "round_robin"
+	TeamAssignmentStrategyWeightedRandom TeamAssignmentStrategy = "weighted_random"
+)
+
+// Defines values for TeamPairingMode.
+const (
+	TeamPairingModeAvoidRecent TeamPairingMode = "avoid_recent"
+	TeamPairingModeIgnore      TeamPairingMode = "ignore"
+)
+
+// Defines values for TeamWorkingHoursMode.
+const (
+	TeamWorkingHoursModeIgnore TeamWorkingHoursMode = "ignore"
+	TeamWorkingHoursModePrefer TeamWorkingHoursMode = "prefer"
+)
+
+// AssignmentPreview defines model for AssignmentPreview.
+type AssignmentPreview struct {
+	// AssignmentSeed seed to pass back as seed to /pullRequest/preview to repeat the draw
+	AssignmentSeed int64 `json:"assignment_seed"`
+
+	// CapacityExhausted a seat would be left empty because members were at their open review limit
+	CapacityExhausted bool `json:"capacity_exhausted,omitempty"`
+
+	// Eligible active members of the author's team that could review
+	Eligible  []TeamMember         `json:"eligible"`
+	Reviewers []ReviewerAssignment `json:"reviewers"`
+
+	// Shortfall required reviewer seats that would be left empty
+	Shortfall int `json:"shortfall,omitempty"`
+
+	// Trace explanation of the picks, returned with ?explain=true
+	Trace *AssignmentTrace `json:"trace,omitempty"`
+}
+
+// AssignmentTrace defines model for AssignmentTrace.
+type AssignmentTrace struct {
+	Action         AssignmentTraceAction `json:"action"`
+	AssignmentSeed int64                 `json:"assignment_seed"`
+	At             time.Time             `json:"at"`
+
+	// CapacityExhausted a seat was left empty because members were at their open review limit
+	CapacityExhausted bool `json:"capacity_exhausted,omitempty"`
+
+	// DryRun the assignment was only previewed and not stored
+	DryRun        bool   `json:"dry_run,omitempty"`
+	PullRequestId string `json:"pull_request_id"`
+
+	// ReplacedUserId reviewer taken off the PR by a reassignment
+	ReplacedUserId string `json:"replaced_user_id,omitempty"`
+
+	// Reviewers reviewers of the PR after the assignment
+	Reviewers []string `json:"reviewers"`
+
+	// Shortfall required reviewer seats left empty because no eligible candidate was left
+	Shortfall int                   `json:"shortfall,omitempty"`
+	Steps     []AssignmentTraceStep `json:"steps"`
+}
+
+// AssignmentTraceAction defines model for AssignmentTrace.Action.
+type AssignmentTraceAction string
+
+// AssignmentTraceStep defines model for AssignmentTraceStep.
+type AssignmentTraceStep struct {
+	Candidates []TraceCandidate `json:"candidates"`
+
+	// MissingSkills required skills not yet covered when the step started
+	MissingSkills []string             `json:"missing_skills,omitempty"`
+	Picked        []string             `json:"picked"`
+	Seats         int                  `json:"seats"`
+	Stage         AssignmentTraceStage `json:"stage"`
+
+	// Strategy assignment strategy that ordered the remaining candidates
+	Strategy TeamAssignmentStrategy `json:"strategy,omitempty"`
+	TeamName string                 `json:"team_name,omitempty"`
+}
+
+// AssignmentTraceStage defines model for AssignmentTraceStep.Stage.
+type AssignmentTraceStage string
+
+// CandidateExclusion defines model for TraceCandidate.Excluded.
+type CandidateExclusion string
+
+// CodeOwners defines model for CodeOwners.
+type CodeOwners struct {
+	Repository string           `json:"repository"`
+	Rules      []CodeOwnersRule `json:"rules"`
+}
+
+// CodeOwnersRule defines model for CodeOwnersRule.
+type CodeOwnersRule struct {
+	Owners  []string `json:"owners"`
+	Pattern string   `json:"pattern"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -40,51 +230,259 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// PairingMatrix defines model for PairingMatrix.
+type PairingMatrix struct {
+	// LookbackDays size of the window the pairings are counted in
+	LookbackDays int `json:"lookback_days"`
+
+	// Matrix number of PRs per author_id and reviewer user_id
+	Matrix   map[string]map[string]int `json:"matrix"`
+	Since    time.Time                 `json:"since"`
+	TeamName string                    `json:"team_name"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id ╨╜╨░╨╖╨╜╨░╤З╨╡╨╜╨╜╤Л╤Е ╤А╨╡╨▓╤М╤О╨▓╨╡╤А╨╛╨▓ (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt"`
+
+	// FilesChanged number of files touched by the diff
+	FilesChanged int      `json:"files_changed,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
+
+	// LinesAdded lines added by the diff
+	LinesAdded int `json:"lines_added,omitempty"`
+
+	// LinesRemoved lines removed by the diff
+	LinesRemoved    int        `json:"lines_removed,omitempty"`
+	MergedAt        *time.Time `json:"mergedAt"`
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+
+	// Repository repository the PR belongs to, e.g. org/service
+	Repository string `json:"repository,omitempty"`
+
+	// ReviewRound current review round; a re-review request starts the next one
+	ReviewRound  int                  `json:"review_round,omitempty"`
+	Reviewers    []ReviewerAssignment `json:"reviewers,omitempty"`
+	SourceBranch string               `json:"source_branch,omitempty"`
+	Status       PullRequestStatus    `json:"status"`
+	TargetBranch string               `json:"target_branch,omitempty"`
+
+	// Url web page of the PR
+	Url string `json:"url,omitempty"`
+}
+
+// PullRequestReviews defines model for PullRequestReviews.
+type PullRequestReviews struct {
+	PullRequestId string        `json:"pull_request_id"`
+	ReviewRound   int           `json:"review_round"`
+	Rounds        []ReviewRound `json:"rounds"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestPage defines model for PullRequestPage.
+type PullRequestPage struct {
+	// NextCursor pass as cursor to fetch the next page; absent on the last page
+	NextCursor   *string       `json:"next_cursor,omitempty"`
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
+// PullRequestSort defines model for PullRequestSort.
+type PullRequestSort string
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
 	AuthorId        string                 `json:"author_id"`
+	Labels          []string               `json:"labels,omitempty"`
 	PullRequestId   string                 `json:"pull_request_id"`
 	PullRequestName string                 `json:"pull_request_name"`
+	Repository      string                 `json:"repository,omitempty"`
 	Status          PullRequestShortStatus `json:"status"`
+	Url             string                 `json:"url,omitempty"`
 }
 
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// RebalanceMove defines model for RebalanceMove.
+type RebalanceMove struct {
+	FromUserId    string `json:"from_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+	ToUserId      string `json:"to_user_id"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	PullRequestId string        `json:"pull_request_id"`
+	Round         int           `json:"round"`
+	SubmittedAt   time.Time     `json:"submitted_at"`
+	UserId        string        `json:"user_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+}
+
+// ReviewVerdict defines model for Review.Verdict.
+type ReviewVerdict string
+
+// RepositoryStatistics defines model for RepositoryStatistics.
+type RepositoryStatistics struct {
+	// AverageSize lines added plus removed per PR
+	AverageSize      float64 `json:"average_size"`
+	Merged           int     `json:"merged"`
+	Open             int     `json:"open"`
+	PullRequests     int     `json:"pull_requests"`
+	TotalAssignments int     `json:"total_assignments"`
+}
+
+// ReviewRound defines model for ReviewRound.
+type ReviewRound struct {
+	// Pending current reviewers without a verdict; only set for the current round
+	Pending []string `json:"pending,omitempty"`
+	Reviews []Review `json:"reviews"`
+	Round   int      `json:"round"`
+}
+
+// ReviewerAssignment defines model for ReviewerAssignment.
+type ReviewerAssignment struct {
+	// AssignmentSeed seed of the random draw that picked the reviewer
+	AssignmentSeed *int64 `json:"assignment_seed,omitempty"`
+
+	// Source whether the reviewer was requested by the author or assigned automatically
+	Source ReviewerSource `json:"source,omitempty"`
+
+	// TeamName team the reviewer was drawn from
+	TeamName string `json:"team_name,omitempty"`
+	UserId   string `json:"user_id"`
+}
+
+// ReviewerSource defines model for ReviewerAssignment.Source.
+type ReviewerSource string
+
+// TraceCandidate defines model for TraceCandidate.
+type TraceCandidate struct {
+	// AtWork set for teams that prefer reviewers inside their working hours
+	AtWork *bool `json:"at_work,omitempty"`
+
+	// Excluded why the member could not be picked; empty for eligible candidates
+	Excluded    CandidateExclusion `json:"excluded,omitempty"`
+	OpenReviews int                `json:"open_reviews"`
+
+	// RecentPairings author's PRs the candidate reviewed within the pairing lookback
+	RecentPairings int    `json:"recent_pairings,omitempty"`
+	UserId         string `json:"user_id"`
+}
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	AssignmentStrategy TeamAssignmentStrategy `json:"assignment_strategy,omitempty"`
+	FallbackTeams      []string               `json:"fallback_teams,omitempty"`
+	MaxOpenReviews     *int                   `json:"max_open_reviews,omitempty"`
+	Members            []TeamMember           `json:"members"`
+
+	// PairingLookbackDays Days of history that count towards repeated author-reviewer pairings
+	PairingLookbackDays int             `json:"pairing_lookback_days,omitempty"`
+	PairingMode         TeamPairingMode `json:"pairing_mode,omitempty"`
+	RequireSenior       bool            `json:"require_senior,omitempty"`
+
+	// RequiredApprovals approvals a PR by a team member needs before it can be merged
+	RequiredApprovals int `json:"required_approvals,omitempty"`
+	RequiredReviewers int `json:"required_reviewers,omitempty"`
+
+	// SizeThresholds reviewer counts by PR size; PRs below the first threshold need required_reviewers
+	SizeThresholds []SizeThreshold `json:"size_thresholds,omitempty"`
+	TeamName       string          `json:"team_name"`
+
+	// WorkingHoursLookahead Hours ahead a reviewer may start work and still count as available
+	WorkingHoursLookahead int                  `json:"working_hours_lookahead,omitempty"`
+	WorkingHoursMode      TeamWorkingHoursMode `json:"working_hours_mode,omitempty"`
+}
+
+// TeamRebalance defines model for TeamRebalance.
+type TeamRebalance struct {
+	// Applied the moves were written; false for a dry run
+	Applied bool            `json:"applied"`
+	Moves   []RebalanceMove `json:"moves"`
+
+	// OpenReviewsAfter open reviews per active member once the moves are done
+	OpenReviewsAfter map[string]int `json:"open_reviews_after"`
+
+	// OpenReviewsBefore open reviews per active member before the moves
+	OpenReviewsBefore map[string]int `json:"open_reviews_before"`
+	TeamName          string         `json:"team_name"`
 }
 
+// TeamAssignmentStrategy defines model for Team.AssignmentStrategy.
+type TeamAssignmentStrategy string
+
+// TeamPairingMode defines model for Team.PairingMode.
+type TeamPairingMode string
+
+// TeamWorkingHoursMode defines model for Team.WorkingHoursMode.
+type TeamWorkingHoursMode string
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive       bool      `json:"is_active"`
+	MaxOpenReviews *int      `json:"max_open_reviews,omitempty"`
+	Seniority      Seniority `json:"seniority,omitempty"`
+	UserId         string    `json:"user_id"`
+	Username       string    `json:"username"`
+}
+
+// Seniority defines model for Seniority.
+type Seniority string
+
+// SizeThreshold defines model for SizeThreshold.
+type SizeThreshold struct {
+	// MinLines smallest PR size, in lines added plus removed, the threshold applies to
+	MinLines  int `json:"min_lines"`
+	Reviewers int `json:"reviewers"`
+}
+
+// SortOrder defines model for SortOrder.
+type SortOrder string
+
+// SkillMatchMode defines model for SkillMatchMode.
+type SkillMatchMode string
+
+// Unavailability defines model for Unavailability.
+type Unavailability struct {
+	// EndDate Last unavailable day, YYYY-MM-DD
+	EndDate string `json:"end_date"`
+	Id      int64  `json:"id"`
+	Reason  string `json:"reason,omitempty"`
+
+	// StartDate First unavailable day, YYYY-MM-DD
+	StartDate string `json:"start_date"`
+	UserId    string `json:"user_id"`
 }
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive       bool          `json:"is_active"`
+	MaxOpenReviews *int          `json:"max_open_reviews,omitempty"`
+	Seniority      Seniority     `json:"seniority,omitempty"`
+	Skills         []string      `json:"skills,omitempty"`
+	TeamName       string        `json:"team_name"`
+	UserId         string        `json:"user_id"`
+	Username       string        `json:"username"`
+	WorkingHours   *WorkingHours `json:"working_hours,omitempty"`
+}
+
+// WorkingHours defines model for WorkingHours.
+type WorkingHours struct {
+	// End Local end of the working day, HH:MM. May be before Start for night shifts
+	End string `json:"end"`
+
+	// Start Local start of the working day, HH:MM
+	Start string `json:"start"`
+
+	// Timezone IANA time zone name, e.g. Europe/Berlin
+	Timezone string `json:"timezone"`
 }
 
 // TeamNameQuery defines model for TeamNameQuery.
@@ -93,22 +491,233 @@ type TeamNameQuery = string
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// RepositoryQuery defines model for RepositoryQuery.
+type RepositoryQuery = string
+
+// PostCodeOwnersSetJSONBody defines parameters for PostCodeOwnersSet.
+type PostCodeOwnersSetJSONBody struct {
+	Content    string `json:"content"`
+	Repository string `json:"repository"`
+}
+
+// GetCodeOwnersGetParams defines parameters for GetCodeOwnersGet.
+type GetCodeOwnersGetParams struct {
+	Repository RepositoryQuery `form:"repository" json:"repository"`
+}
+
+// PostPullRequestAddReviewerJSONBody defines parameters for PostPullRequestAddReviewer.
+type PostPullRequestAddReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
-	PullRequestId   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+	AuthorId     string   `json:"author_id"`
+	ChangedFiles []string `json:"changed_files,omitempty"`
+
+	// Draft creates the PR as DRAFT without reviewers
+	Draft bool `json:"draft,omitempty"`
+
+	// FilesChanged defaults to the number of changed_files
+	FilesChanged    int      `json:"files_changed,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	LinesAdded      int      `json:"lines_added,omitempty"`
+	LinesRemoved    int      `json:"lines_removed,omitempty"`
+	PullRequestId   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	Repository      string   `json:"repository,omitempty"`
+
+	// RequestedReviewers reviewers the author asks for; the remaining seats are filled automatically
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	RequiredSkills     []string `json:"required_skills,omitempty"`
+
+	SkillMatch   *SkillMatchMode `json:"skill_match,omitempty"`
+	SourceBranch string          `json:"source_branch,omitempty"`
+	TargetBranch string          `json:"target_branch,omitempty"`
+	Url          string          `json:"url,omitempty"`
+}
+
+// PostPullRequestPreviewJSONBody defines parameters for PostPullRequestPreview.
+type PostPullRequestPreviewJSONBody struct {
+	AuthorId     string   `json:"author_id"`
+	ChangedFiles []string `json:"changed_files,omitempty"`
+
+	// FilesChanged defaults to the number of changed_files
+	FilesChanged    int      `json:"files_changed,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	LinesAdded      int      `json:"lines_added,omitempty"`
+	LinesRemoved    int      `json:"lines_removed,omitempty"`
+	PullRequestId   string   `json:"pull_request_id,omitempty"`
+	PullRequestName string   `json:"pull_request_name,omitempty"`
+	Repository      string   `json:"repository,omitempty"`
+
+	// RequestedReviewers reviewers the author asks for; the remaining seats are filled automatically
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	RequiredSkills     []string `json:"required_skills,omitempty"`
+
+	// Seed replays the random draw of an earlier preview
+	Seed         *int64          `json:"seed,omitempty"`
+	SkillMatch   *SkillMatchMode `json:"skill_match,omitempty"`
+	SourceBranch string          `json:"source_branch,omitempty"`
+	TargetBranch string          `json:"target_branch,omitempty"`
+	Url          string          `json:"url,omitempty"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestMarkReadyJSONBody defines parameters for PostPullRequestMarkReady.
+type PostPullRequestMarkReadyJSONBody struct {
+	ChangedFiles  []string `json:"changed_files,omitempty"`
+	PullRequestId string   `json:"pull_request_id"`
+	Repository    string   `json:"repository,omitempty"`
+
+	// RequestedReviewers reviewers the author asks for; the remaining seats are filled automatically
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	RequiredSkills     []string `json:"required_skills,omitempty"`
+
+	SkillMatch *SkillMatchMode `json:"skill_match,omitempty"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// Force merges without checking the reviews; requires the X-Admin-Token header
+	Force         bool   `json:"force,omitempty"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestRemoveReviewerJSONBody defines parameters for PostPullRequestRemoveReviewer.
+type PostPullRequestRemoveReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+}
+
+// PostPullRequestRequestReReviewJSONBody defines parameters for PostPullRequestRequestReReview.
+type PostPullRequestRequestReReviewJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestUpdateSizeJSONBody defines parameters for PostPullRequestUpdateSize.
+type PostPullRequestUpdateSizeJSONBody struct {
+	// ChangedFiles files of the diff, matched against CODEOWNERS when reviewers are topped up
+	ChangedFiles []string `json:"changed_files,omitempty"`
+
+	// FilesChanged new number of changed files; left unchanged when omitted
+	FilesChanged   *int            `json:"files_changed,omitempty"`
+	LinesAdded     int             `json:"lines_added"`
+	LinesRemoved   int             `json:"lines_removed"`
+	PullRequestId  string          `json:"pull_request_id"`
+	RequiredSkills []string        `json:"required_skills,omitempty"`
+	SkillMatch     *SkillMatchMode `json:"skill_match,omitempty"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	PullRequestId string        `json:"pull_request_id"`
+	UserId        string        `json:"user_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
-	OldUserId     string `json:"old_user_id"`
-	PullRequestId string `json:"pull_request_id"`
+	// DryRun picks the replacement without storing it
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// ExcludeUserIds users that must not become the replacement
+	ExcludeUserIds []string `json:"exclude_user_ids,omitempty"`
+
+	// NewUserId replacement chosen by the caller instead of a draw
+	NewUserId     *string `json:"new_user_id,omitempty"`
+	OldUserId     string  `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
+// PostTeamRebalanceJSONBody defines parameters for PostTeamRebalance.
+type PostTeamRebalanceJSONBody struct {
+	// Apply performs the planned moves; without it only the plan is returned
+	Apply    bool   `json:"apply,omitempty"`
+	TeamName string `json:"team_name"`
+}
+
+// PostTeamUpdateJSONBody defines parameters for PostTeamUpdate.
+type PostTeamUpdateJSONBody struct {
+	AssignmentStrategy *TeamAssignmentStrategy `json:"assignment_strategy,omitempty"`
+	FallbackTeams      *[]string               `json:"fallback_teams,omitempty"`
+	MaxOpenReviews     *int                    `json:"max_open_reviews,omitempty"`
+
+	PairingLookbackDays *int             `json:"pairing_lookback_days,omitempty"`
+	PairingMode         *TeamPairingMode `json:"pairing_mode,omitempty"`
+
+	RequireSenior     *bool `json:"require_senior,omitempty"`
+	RequiredApprovals *int  `json:"required_approvals,omitempty"`
+	RequiredReviewers *int  `json:"required_reviewers,omitempty"`
+
+	// SizeThresholds replaces the team's size thresholds; an empty list removes them
+	SizeThresholds *[]SizeThreshold `json:"size_thresholds,omitempty"`
+	TeamName       string           `json:"team_name"`
+
+	WorkingHoursLookahead *int                  `json:"working_hours_lookahead,omitempty"`
+	WorkingHoursMode      *TeamWorkingHoursMode `json:"working_hours_mode,omitempty"`
+}
+
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	Status   *PullRequestStatus `form:"status,omitempty" json:"status,omitempty"`
+	AuthorId *string            `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// ReviewerId PRs the user is assigned to review
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName PRs whose author belongs to the team
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// CreatedFrom earliest created_at, inclusive
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo latest created_at, inclusive
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom earliest merged_at, inclusive; PRs that are not merged are left out
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo latest merged_at, inclusive; PRs that are not merged are left out
+	MergedTo     *time.Time       `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+	Repository   *RepositoryQuery `form:"repository,omitempty" json:"repository,omitempty"`
+	Label        *string          `form:"label,omitempty" json:"label,omitempty"`
+	SourceBranch *string          `form:"source_branch,omitempty" json:"source_branch,omitempty"`
+	TargetBranch *string          `form:"target_branch,omitempty" json:"target_branch,omitempty"`
+
+	// MinSize smallest lines added plus removed
+	MinSize *int `form:"min_size,omitempty" json:"min_size,omitempty"`
+
+	// MaxSize largest lines added plus removed
+	MaxSize *int             `form:"max_size,omitempty" json:"max_size,omitempty"`
+	Sort    *PullRequestSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Order   *SortOrder       `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit page size, 1 to 100 (default 20)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestReviewsParams defines parameters for GetPullRequestReviews.
+type GetPullRequestReviewsParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
 }
 
 // GetTeamGetParams defines parameters for GetTeamGet.
@@ -121,37 +730,187 @@ type GetTeamGetParams struct {
 type GetUsersGetReviewParams struct {
 	// UserId ╨Ш╨┤╨╡╨╜╤В╨╕╤Д╨╕╨║╨░╤В╨╛╤А ╨┐╨╛╨╗╤М╨╖╨╛╨▓╨░╤В╨╡╨╗╤П
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	Repository   *RepositoryQuery `form:"repository,omitempty" json:"repository,omitempty"`
+	Label        *string          `form:"label,omitempty" json:"label,omitempty"`
+	SourceBranch *string          `form:"source_branch,omitempty" json:"source_branch,omitempty"`
+	TargetBranch *string          `form:"target_branch,omitempty" json:"target_branch,omitempty"`
+
+	// MinSize smallest lines added plus removed
+	MinSize *int `form:"min_size,omitempty" json:"min_size,omitempty"`
+
+	// MaxSize largest lines added plus removed
+	MaxSize *int `form:"max_size,omitempty" json:"max_size,omitempty"`
+}
+
+// GetPullRequestExplainParams defines parameters for GetPullRequestExplain.
+type GetPullRequestExplainParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetTeamPairingsParams defines parameters for GetTeamPairings.
+type GetTeamPairingsParams struct {
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+
+	// LookbackDays overrides the team's pairing_lookback_days
+	LookbackDays *int `form:"lookback_days,omitempty" json:"lookback_days,omitempty"`
+}
+
+// GetUsersGetUnavailabilityParams defines parameters for GetUsersGetUnavailability.
+type GetUsersGetUnavailabilityParams struct {
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersAddUnavailabilityJSONBody defines parameters for PostUsersAddUnavailability.
+type PostUsersAddUnavailabilityJSONBody struct {
+	EndDate string `json:"end_date"`
+	Reason  string `json:"reason,omitempty"`
+
+	// ReassignReviews Move the user's open reviews to other reviewers if the range is under way or starts within two days
+	ReassignReviews bool   `json:"reassign_reviews,omitempty"`
+	StartDate       string `json:"start_date"`
+	UserId          string `json:"user_id"`
+}
+
+// PostUsersDeleteUnavailabilityJSONBody defines parameters for PostUsersDeleteUnavailability.
+type PostUsersDeleteUnavailabilityJSONBody struct {
+	Id int64 `json:"id"`
+}
+
+// PostUsersUpdateUnavailabilityJSONBody defines parameters for PostUsersUpdateUnavailability.
+type PostUsersUpdateUnavailabilityJSONBody struct {
+	EndDate   string `json:"end_date"`
+	Id        int64  `json:"id"`
+	Reason    string `json:"reason,omitempty"`
+	StartDate string `json:"start_date"`
 }
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
+	IsActive bool `json:"is_active"`
+
+	// ReassignReviews on deactivation, move the user's OPEN reviews to other reviewers
+	ReassignReviews bool   `json:"reassign_reviews,omitempty"`
+	UserId          string `json:"user_id"`
+}
+
+// PostUsersSetMaxOpenReviewsJSONBody defines parameters for PostUsersSetMaxOpenReviews.
+type PostUsersSetMaxOpenReviewsJSONBody struct {
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	UserId         string `json:"user_id"`
+}
+
+// PostUsersSetWorkingHoursJSONBody defines parameters for PostUsersSetWorkingHours.
+type PostUsersSetWorkingHoursJSONBody struct {
+	UserId string `json:"user_id"`
+
+	// WorkingHours New working hours, null clears them
+	WorkingHours *WorkingHours `json:"working_hours"`
+}
+
+// PostUsersSetSeniorityJSONBody defines parameters for PostUsersSetSeniority.
+type PostUsersSetSeniorityJSONBody struct {
+	Seniority Seniority `json:"seniority"`
+	UserId    string    `json:"user_id"`
+}
+
+// PostUsersSetSkillsJSONBody defines parameters for PostUsersSetSkills.
+type PostUsersSetSkillsJSONBody struct {
+	Skills []string `json:"skills"`
+	UserId string   `json:"user_id"`
 }
 
+// PostCodeOwnersSetJSONRequestBody defines body for PostCodeOwnersSet for application/json ContentType.
+type PostCodeOwnersSetJSONRequestBody PostCodeOwnersSetJSONBody
+
+// PostPullRequestAddReviewerJSONRequestBody defines body for PostPullRequestAddReviewer for application/json ContentType.
+type PostPullRequestAddReviewerJSONRequestBody PostPullRequestAddReviewerJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
+// PostPullRequestMarkReadyJSONRequestBody defines body for PostPullRequestMarkReady for application/json ContentType.
+type PostPullRequestMarkReadyJSONRequestBody PostPullRequestMarkReadyJSONBody
+
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestPreviewJSONRequestBody defines body for PostPullRequestPreview for application/json ContentType.
+type PostPullRequestPreviewJSONRequestBody PostPullRequestPreviewJSONBody
+
+// PostPullRequestRemoveReviewerJSONRequestBody defines body for PostPullRequestRemoveReviewer for application/json ContentType.
+type PostPullRequestRemoveReviewerJSONRequestBody PostPullRequestRemoveReviewerJSONBody
+
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestRequestReReviewJSONRequestBody defines body for PostPullRequestRequestReReview for application/json ContentType.
+type PostPullRequestRequestReReviewJSONRequestBody PostPullRequestRequestReReviewJSONBody
+
+// PostPullRequestUpdateSizeJSONRequestBody defines body for PostPullRequestUpdateSize for application/json ContentType.
+type PostPullRequestUpdateSizeJSONRequestBody PostPullRequestUpdateSizeJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamRebalanceJSONRequestBody defines body for PostTeamRebalance for application/json ContentType.
+type PostTeamRebalanceJSONRequestBody PostTeamRebalanceJSONBody
+
+// PostTeamUpdateJSONRequestBody defines body for PostTeamUpdate for application/json ContentType.
+type PostTeamUpdateJSONRequestBody PostTeamUpdateJSONBody
+
+// PostUsersAddUnavailabilityJSONRequestBody defines body for PostUsersAddUnavailability for application/json ContentType.
+type PostUsersAddUnavailabilityJSONRequestBody PostUsersAddUnavailabilityJSONBody
+
+// PostUsersDeleteUnavailabilityJSONRequestBody defines body for PostUsersDeleteUnavailability for application/json ContentType.
+type PostUsersDeleteUnavailabilityJSONRequestBody PostUsersDeleteUnavailabilityJSONBody
+
+// PostUsersUpdateUnavailabilityJSONRequestBody defines body for PostUsersUpdateUnavailability for application/json ContentType.
+type PostUsersUpdateUnavailabilityJSONRequestBody PostUsersUpdateUnavailabilityJSONBody
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
 
+// PostUsersSetMaxOpenReviewsJSONRequestBody defines body for PostUsersSetMaxOpenReviews for application/json ContentType.
+type PostUsersSetMaxOpenReviewsJSONRequestBody PostUsersSetMaxOpenReviewsJSONBody
+
+// PostUsersSetWorkingHoursJSONRequestBody defines body for PostUsersSetWorkingHours for application/json ContentType.
+type PostUsersSetWorkingHoursJSONRequestBody PostUsersSetWorkingHoursJSONBody
+
+// PostUsersSetSeniorityJSONRequestBody defines body for PostUsersSetSeniority for application/json ContentType.
+type PostUsersSetSeniorityJSONRequestBody PostUsersSetSeniorityJSONBody
+
+// PostUsersSetSkillsJSONRequestBody defines body for PostUsersSetSkills for application/json ContentType.
+type PostUsersSetSkillsJSONRequestBody PostUsersSetSkillsJSONBody
+
 // Statistics defines model for Statistics response
 type Statistics struct {
 	TotalAssignments int            `json:"total_assignments"`
 	ByUser           map[string]int `json:"by_user"`
 	ByStatus         struct {
+		Draft  int `json:"draft"`
 		Open   int `json:"open"`
 		Merged int `json:"merged"`
+		Closed int `json:"closed"`
 	} `json:"by_status"`
+
+	// AverageReviewRounds review rounds per PR that went into review
+	AverageReviewRounds float64 `json:"average_review_rounds"`
+
+	// AverageReviewRoundsByAuthor review rounds per PR of each author
+	AverageReviewRoundsByAuthor map[string]float64 `json:"average_review_rounds_by_author"`
+
+	// ByRepository PRs and assignments per repository; PRs without one are left out
+	ByRepository map[string]RepositoryStatistics `json:"by_repository"`
 }
 
 // BatchDeactivateRequest defines body for batch deactivation
@@ -172,3 +931,101 @@ type BatchDeactivateResponse struct {
 
 // PostUsersDeactivateBatchJSONRequestBody defines body for batch deactivation endpoint
 type PostUsersDeactivateBatchJSONRequestBody = BatchDeactivateRequest
+
+// CreatePROptions carries the optional inputs of PR creation that are not
+// stored on the PR itself
+type CreatePROptions struct {
+	// Draft stores the PR as DRAFT and assigns no reviewers
+	Draft          bool
+	Repository     string
+	ChangedFiles   []string
+	RequiredSkills []string
+	SkillMatch     SkillMatchMode
+	// RequestedReviewers are assigned before any automatic pick
+	RequestedReviewers []string
+	// Seed replays the draw of an earlier preview instead of taking the next
+	// seed; only previews set it
+	Seed *int64
+}
+
+// PullRequestFilter narrows a PR listing by metadata; zero fields match any PR
+type PullRequestFilter struct {
+	Repository   string
+	Label        string
+	SourceBranch string
+	TargetBranch string
+	// MinSize and MaxSize bound the lines added plus removed
+	MinSize *int
+	MaxSize *int
+}
+
+// PullRequestListQuery selects one page of a PR listing; zero filter fields
+// match any PR
+type PullRequestListQuery struct {
+	Status      PullRequestStatus
+	AuthorId    string
+	ReviewerId  string
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	// Filter narrows the listing by repository, label, branches and size
+	Filter PullRequestFilter
+	Sort   PullRequestSort
+	Order  SortOrder
+	Limit  int
+	// Cursor is the opaque next_cursor of the previous page
+	Cursor string
+}
+
+// PullRequestCursor is the position of a PR in a listing: the sort it was
+// listed by, its sort key and its ID, which breaks ties between equal keys
+type PullRequestCursor struct {
+	Sort          PullRequestSort `json:"sort"`
+	Order         SortOrder       `json:"order"`
+	Key           string          `json:"key"`
+	PullRequestId string          `json:"pull_request_id"`
+}
+
+// ReassignOptions carries the optional inputs of a reviewer reassignment
+type ReassignOptions struct {
+	// DryRun picks the replacement without storing it
+	DryRun bool
+	// NewUserID is the replacement to take instead of a draw; it must pass
+	// the same candidate checks
+	NewUserID string
+	// ExcludeUserIDs must not become the replacement
+	ExcludeUserIDs []string
+}
+
+// ReviewReassignment is the outcome of moving one open review off a user.
+type ReviewReassignment struct {
+	PullRequestId string  `json:"pull_request_id"`
+	ReplacedBy    *string `json:"replaced_by,omitempty"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// UserDeactivation is the outcome of deactivating a single user.
+type UserDeactivation struct {
+	User *User `json:"user"`
+	// Reassigned is set when the user's open reviews were handed over
+	Reassigned []ReviewReassignment `json:"reassigned,omitempty"`
+	// ReassignError is set when the user was deactivated but the open
+	// reviews could not be listed
+	ReassignError string `json:"reassign_error,omitempty"`
+}
+
+// Valid indicates whether the value is a known member of the Seniority enum.
+func (s Seniority) Valid() bool {
+	switch s {
+	case SeniorityJunior, SeniorityMid, SenioritySenior, SeniorityLead:
+		return true
+	}
+	return false
+}
+
+// IsSenior reports whether the level counts as senior or above.
+func (s Seniority) IsSenior() bool {
+	return s == SenioritySenior || s == SeniorityLead
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/V1merX/pr-reviewer-service/internal/repository"
 	pgrepo "github.com/V1merX/pr-reviewer-service/internal/repository/postgres"
 	"github.com/V1merX/pr-reviewer-service/internal/service"
+	codeownersService "github.com/V1merX/pr-reviewer-service/internal/service/codeowners"
 	pullrequestService "github.com/V1merX/pr-reviewer-service/internal/service/pullrequest"
 	teamService "github.com/V1merX/pr-reviewer-service/internal/service/team"
 	userService "github.com/V1merX/pr-reviewer-service/internal/service/user"
@@ -27,13 +28,15 @@ type diContainer struct {
 	db     *sqlx.DB
 	logger *slog.Logger
 
-	teamRepo repository.TeamRepository
-	userRepo repository.UserRepository
-	prRepo   repository.PullRequestRepository
+	teamRepo       repository.TeamRepository
+	userRepo       repository.UserRepository
+	prRepo         repository.PullRequestRepository
+	codeOwnersRepo repository.CodeOwnersRepository
 
-	teamService service.TeamService
-	userService service.UserService
-	prService   service.PullRequestService
+	teamService       service.TeamService
+	userService       service.UserService
+	prService         service.PullRequestService
+	codeOwnersService service.CodeOwnersService
 
 	httpServer *httpserver.Server
 	cfgPath    string
@@ -129,6 +132,17 @@ func (d *diContainer) PullRequestRepository() (repository.PullRequestRepository,
 	return d.prRepo, nil
 }
 
+func (d *diContainer) CodeOwnersRepository() (repository.CodeOwnersRepository, error) {
+	if d.codeOwnersRepo == nil {
+		db, err := d.DB()
+		if err != nil {
+			return nil, err
+		}
+		d.codeOwnersRepo = pgrepo.NewCodeOwnersRepository(db, d.Logger(d.cfg.Server.Env))
+	}
+	return d.codeOwnersRepo, nil
+}
+
 func (d *diContainer) TeamService() (service.TeamService, error) {
 	if d.teamService == nil {
 		repo, err := d.TeamRepository()
@@ -165,11 +179,30 @@ func (d *diContainer) PullRequestService() (service.PullRequestService, error) {
 		if err != nil {
 			return nil, err
 		}
-		d.prService = pullrequestService.NewService(d.Logger(d.cfg.Server.Env), prRepo, teamRepo, userRepo)
+		codeOwnersRepo, err := d.CodeOwnersRepository()
+		if err != nil {
+			return nil, err
+		}
+		var opts []pullrequestService.Option
+		if seed := d.cfg.Assignment.RandomSeed; seed != 0 {
+			opts = append(opts, pullrequestService.WithSeedSource(pullrequestService.NewSeedSource(seed)))
+		}
+		d.prService = pullrequestService.NewService(d.Logger(d.cfg.Server.Env), prRepo, teamRepo, userRepo, codeOwnersRepo, opts...)
 	}
 	return d.prService, nil
 }
 
+func (d *diContainer) CodeOwnersService() (service.CodeOwnersService, error) {
+	if d.codeOwnersService == nil {
+		repo, err := d.CodeOwnersRepository()
+		if err != nil {
+			return nil, err
+		}
+		d.codeOwnersService = codeownersService.NewService(d.Logger(d.cfg.Server.Env), repo)
+	}
+	return d.codeOwnersService, nil
+}
+
 func (d *diContainer) HTTPServer() (*httpserver.Server, error) {
 	if d.httpServer == nil {
 		cfg, err := d.Config()
@@ -189,7 +222,11 @@ func (d *diContainer) HTTPServer() (*httpserver.Server, error) {
 		if err != nil {
 			return nil, err
 		}
-		d.httpServer = httpserver.New(cfg, logger, teamSvc, userSvc, prSvc)
+		codeOwnersSvc, err := d.CodeOwnersService()
+		if err != nil {
+			return nil, err
+		}
+		d.httpServer = httpserver.New(cfg, logger, teamSvc, userSvc, prSvc, codeOwnersSvc)
 	}
 	return d.httpServer, nil
 }
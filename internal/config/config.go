@@ -8,13 +8,24 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Assignment AssignmentConfig `mapstructure:"assignment"`
 }
 
+// ServerConfig configures the HTTP server. Requests carrying AdminToken in
+// the X-Admin-Token header may use admin-only options such as a forced merge;
+// an empty AdminToken disables them.
 type ServerConfig struct {
-	Port string `mapstructure:"port"`
-	Env  string `mapstructure:"env"`
+	Port       string `mapstructure:"port"`
+	Env        string `mapstructure:"env"`
+	AdminToken string `mapstructure:"admin_token"`
+}
+
+// AssignmentConfig tunes reviewer selection. A non-zero RandomSeed makes the
+// sequence of assignment seeds reproducible across restarts.
+type AssignmentConfig struct {
+	RandomSeed uint64 `mapstructure:"random_seed"`
 }
 
 type DatabaseConfig struct {
@@ -0,0 +1,64 @@
+package codeowners
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/http/handler/response"
+	"github.com/V1merX/pr-reviewer-service/internal/service"
+	codeownersService "github.com/V1merX/pr-reviewer-service/internal/service/codeowners"
+)
+
+type Handler struct {
+	svc service.CodeOwnersService
+}
+
+func New(svc service.CodeOwnersService) *Handler {
+	return &Handler{svc: svc}
+}
+
+func (h *Handler) PostCodeOwnersSet(w http.ResponseWriter, r *http.Request) {
+	var req api.PostCodeOwnersSetJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	codeOwners, err := h.svc.SetCodeOwners(req.Repository, req.Content)
+	if err != nil {
+		switch {
+		case errors.Is(err, codeownersService.ErrRepositoryRequired):
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "repository is required")
+		case errors.Is(err, codeownersService.ErrInvalidPattern), errors.Is(err, codeownersService.ErrInvalidOwner):
+			response.WriteError(w, http.StatusBadRequest, "INVALID_CODEOWNERS", err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("codeowners: set failed", "error", err)
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"codeowners": codeOwners})
+}
+
+func (h *Handler) GetCodeOwnersGet(w http.ResponseWriter, _ *http.Request, params api.GetCodeOwnersGetParams) {
+	if params.Repository == "" {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "repository parameter is required")
+		return
+	}
+
+	codeOwners, err := h.svc.GetCodeOwners(params.Repository)
+	if err != nil {
+		if errors.Is(err, codeownersService.ErrCodeOwnersNotFound) {
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "CODEOWNERS not found")
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		slog.Error("codeowners: get failed", "error", err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, codeOwners)
+}
@@ -1,9 +1,11 @@
 package pullrequest
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
 	"github.com/V1merX/pr-reviewer-service/internal/http/handler/response"
@@ -11,18 +13,39 @@ import (
 )
 
 type Handler struct {
-	prSvc service.PullRequestService
+	prSvc      service.PullRequestService
+	adminToken string
 }
 
-func New(prSvc service.PullRequestService) *Handler {
-	return &Handler{prSvc: prSvc}
+func New(prSvc service.PullRequestService, adminToken string) *Handler {
+	return &Handler{prSvc: prSvc, adminToken: adminToken}
+}
+
+// isAdmin reports whether the request carries the configured admin token.
+// Nobody is an admin when no token is configured.
+func (h *Handler) isAdmin(r *http.Request) bool {
+	token := r.Header.Get("X-Admin-Token")
+	return h.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
 }
 
 func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID      string             `json:"pull_request_id"`
+		Draft              bool               `json:"draft"`
+		PullRequestName    string             `json:"pull_request_name"`
+		AuthorID           string             `json:"author_id"`
+		Repository         string             `json:"repository"`
+		Url                string             `json:"url"`
+		SourceBranch       string             `json:"source_branch"`
+		TargetBranch       string             `json:"target_branch"`
+		Labels             []string           `json:"labels"`
+		LinesAdded         int                `json:"lines_added"`
+		LinesRemoved       int                `json:"lines_removed"`
+		FilesChanged       int                `json:"files_changed"`
+		ChangedFiles       []string           `json:"changed_files"`
+		RequiredSkills     []string           `json:"required_skills"`
+		RequestedReviewers []string           `json:"requested_reviewers"`
+		SkillMatch         api.SkillMatchMode `json:"skill_match"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
@@ -42,59 +65,288 @@ func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request)
 		PullRequestId:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorId:        req.AuthorID,
+		Repository:      req.Repository,
+		Url:             req.Url,
+		SourceBranch:    req.SourceBranch,
+		TargetBranch:    req.TargetBranch,
+		Labels:          req.Labels,
+		LinesAdded:      req.LinesAdded,
+		LinesRemoved:    req.LinesRemoved,
+		FilesChanged:    req.FilesChanged,
+	}
+
+	opts := api.CreatePROptions{
+		Draft:              req.Draft,
+		Repository:         req.Repository,
+		ChangedFiles:       req.ChangedFiles,
+		RequiredSkills:     req.RequiredSkills,
+		SkillMatch:         req.SkillMatch,
+		RequestedReviewers: req.RequestedReviewers,
+	}
+
+	trace, err := h.prSvc.CreatePR(pr, opts)
+	if err != nil {
+		writeAssignmentError(w, "create", err)
+		return
+	}
+
+	resp := map[string]interface{}{"pr": pr}
+	addShortfall(resp, trace)
+	if explainRequested(r) {
+		resp["trace"] = trace
+	}
+	response.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) PostPullRequestPreview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestPreviewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr := &api.PullRequest{
+		PullRequestId:   req.PullRequestId,
+		PullRequestName: req.PullRequestName,
+		AuthorId:        req.AuthorId,
+		Repository:      req.Repository,
+		Url:             req.Url,
+		SourceBranch:    req.SourceBranch,
+		TargetBranch:    req.TargetBranch,
+		Labels:          req.Labels,
+		LinesAdded:      req.LinesAdded,
+		LinesRemoved:    req.LinesRemoved,
+		FilesChanged:    req.FilesChanged,
+	}
+
+	opts := api.CreatePROptions{
+		Repository:         req.Repository,
+		ChangedFiles:       req.ChangedFiles,
+		RequiredSkills:     req.RequiredSkills,
+		RequestedReviewers: req.RequestedReviewers,
+		Seed:               req.Seed,
+	}
+	if req.SkillMatch != nil {
+		opts.SkillMatch = *req.SkillMatch
+	}
+
+	preview, err := h.prSvc.PreviewPR(pr, opts)
+	if err != nil {
+		writeAssignmentError(w, "preview", err)
+		return
+	}
+
+	if !explainRequested(r) {
+		preview.Trace = nil
+	}
+	response.WriteJSON(w, http.StatusOK, preview)
+}
+
+// writeAssignmentError maps the errors of picking reviewers for a new PR.
+func writeAssignmentError(w http.ResponseWriter, op string, err error) {
+	switch err.Error() {
+	case "author not found", "author has no team":
+		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "Author or team not found")
+	case "unknown skill match mode":
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "skill_match must be prefer or require")
+	case "PR size must not be negative":
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "lines_added, lines_removed and files_changed must not be negative")
+	case "PR url must be an http(s) URL":
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "url must be an absolute http(s) URL")
+	case "invalid skill":
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "required_skills must be non-empty tags without spaces")
+	case "no reviewer covers required skills":
+		response.WriteError(w, http.StatusConflict, "SKILLS_NOT_COVERED", "no available reviewer covers the required skills")
+	case "no senior reviewer available":
+		response.WriteError(w, http.StatusConflict, "NO_SENIOR", "team requires a senior reviewer but none is available")
+	case "requested reviewer not found":
+		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "requested reviewer not found")
+	case "requested reviewer is inactive", "author cannot review own PR", "requested reviewer is outside allowed teams":
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REVIEWER", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		slog.Error("pr: "+op+" failed", "error", err)
+	}
+}
+
+func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestMergeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
 	}
 
-	if err := h.prSvc.CreatePR(pr); err != nil {
-		if err.Error() == "author not found" || err.Error() == "author has no team" {
-			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "Author or team not found")
-		} else {
+	if req.Force && !h.isAdmin(r) {
+		response.WriteError(w, http.StatusForbidden, "FORBIDDEN", "force requires the admin token")
+		return
+	}
+
+	pr, err := h.prSvc.MergePR(req.PullRequestId, req.Force)
+	if err != nil {
+		switch err.Error() {
+		case "PR does not have the required approvals":
+			response.WriteError(w, http.StatusConflict, "MERGE_BLOCKED", "PR does not have the approvals its team requires")
+		case "a reviewer requested changes":
+			response.WriteError(w, http.StatusConflict, "MERGE_BLOCKED", "a reviewer has changes requested")
+		default:
+			writeTransitionError(w, "merge", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+func (h *Handler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReviewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	review, err := h.prSvc.SubmitReview(req.PullRequestId, req.UserId, req.Verdict)
+	if err != nil {
+		switch err.Error() {
+		case "unknown review verdict":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "verdict must be APPROVED, CHANGES_REQUESTED or COMMENTED")
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		case "PR is not open":
+			response.WriteError(w, http.StatusConflict, "PR_NOT_OPEN", "reviews can only be submitted on OPEN PRs")
+		case "reviewer is not assigned to this PR":
+			response.WriteError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		default:
 			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
-			slog.Error("pr: create failed", "error", err)
+			slog.Error("pr: review failed", "error", err)
 		}
 		return
 	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"review": review})
+}
+
+func (h *Handler) PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestMarkReadyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	opts := api.CreatePROptions{
+		Repository:         req.Repository,
+		ChangedFiles:       req.ChangedFiles,
+		RequiredSkills:     req.RequiredSkills,
+		RequestedReviewers: req.RequestedReviewers,
+	}
+	if req.SkillMatch != nil {
+		opts.SkillMatch = *req.SkillMatch
+	}
 
-	response.WriteJSON(w, http.StatusCreated, map[string]interface{}{"pr": pr})
+	pr, trace, err := h.prSvc.MarkReady(req.PullRequestId, opts)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found", "invalid PR status transition":
+			writeTransitionError(w, "mark ready", err)
+		default:
+			writeAssignmentError(w, "mark ready", err)
+		}
+		return
+	}
+
+	resp := map[string]interface{}{"pr": pr}
+	addShortfall(resp, trace)
+	if explainRequested(r) {
+		resp["trace"] = trace
+	}
+	response.WriteJSON(w, http.StatusOK, resp)
 }
 
-func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID string `json:"pull_request_id"`
+func (h *Handler) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestCloseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
 	}
+
+	pr, err := h.prSvc.ClosePR(req.PullRequestId)
+	if err != nil {
+		writeTransitionError(w, "close", err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+func (h *Handler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReopenJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
 		return
 	}
 
-	pr, err := h.prSvc.MergePR(req.PullRequestID)
+	pr, err := h.prSvc.ReopenPR(req.PullRequestId)
 	if err != nil {
-		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		switch err.Error() {
+		case "PR not found", "invalid PR status transition":
+			writeTransitionError(w, "reopen", err)
+		default:
+			writeAssignmentError(w, "reopen", err)
+		}
 		return
 	}
 	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
 }
 
+// writeTransitionError maps the errors of moving a PR between statuses.
+func writeTransitionError(w http.ResponseWriter, op string, err error) {
+	switch err.Error() {
+	case "PR not found":
+		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+	case "invalid PR status transition":
+		response.WriteError(w, http.StatusConflict, "INVALID_TRANSITION", "PR cannot move to that status from its current one")
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		slog.Error("pr: "+op+" failed", "error", err)
+	}
+}
+
 func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		PullRequestID string `json:"pull_request_id"`
-		OldUserID     string `json:"old_user_id"`
+		PullRequestID  string   `json:"pull_request_id"`
+		OldUserID      string   `json:"old_user_id"`
+		NewUserID      string   `json:"new_user_id"`
+		ExcludeUserIDs []string `json:"exclude_user_ids"`
+		DryRun         bool     `json:"dry_run"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
 		return
 	}
 
-	pr, newReviewer, err := h.prSvc.ReassignReviewer(req.PullRequestID, req.OldUserID)
+	pr, newReviewer, trace, err := h.prSvc.ReassignReviewer(req.PullRequestID, req.OldUserID, api.ReassignOptions{
+		DryRun:         req.DryRun,
+		NewUserID:      req.NewUserID,
+		ExcludeUserIDs: req.ExcludeUserIDs,
+	})
 	if err != nil {
 		switch err.Error() {
 		case "PR not found":
 			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
 		case "cannot reassign on merged PR":
 			response.WriteError(w, http.StatusConflict, "PR_MERGED", "cannot reassign on merged PR")
+		case "PR is not open":
+			response.WriteError(w, http.StatusConflict, "PR_NOT_OPEN", "reviewers can only be reassigned on OPEN PRs")
 		case "reviewer is not assigned to this PR":
 			response.WriteError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
 		case "no active replacement candidate in team":
 			response.WriteError(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
+		case "reviewer capacity exhausted":
+			response.WriteError(w, http.StatusConflict, "CAPACITY_EXHAUSTED", "replacement candidates are at their open review limit")
+		case "no senior reviewer available":
+			response.WriteError(w, http.StatusConflict, "NO_SENIOR", "replacing the only senior reviewer requires another senior reviewer")
+		case "requested reviewer not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "new reviewer not found")
+		case "reviewer is already assigned to this PR":
+			response.WriteError(w, http.StatusConflict, "ALREADY_ASSIGNED", "new reviewer is already assigned to this PR")
+		case "replacement reviewer is not eligible", "replacement reviewer is outside allowed teams":
+			response.WriteError(w, http.StatusConflict, "NOT_ELIGIBLE", err.Error())
 		default:
 			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
 			slog.Error("pr: reassign failed", "error", err)
@@ -102,7 +354,289 @@ func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr, "replaced_by": *newReviewer})
+	resp := map[string]interface{}{"pr": pr, "replaced_by": *newReviewer}
+	if req.DryRun {
+		resp["dry_run"] = true
+	}
+	if explainRequested(r) {
+		resp["trace"] = trace
+	}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestAddReviewerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr, err := h.prSvc.AddReviewer(req.PullRequestId, req.UserId)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		case "requested reviewer not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "reviewer not found")
+		case "cannot change reviewers on merged PR":
+			response.WriteError(w, http.StatusConflict, "PR_MERGED", "cannot change reviewers on merged PR")
+		case "PR is not open":
+			response.WriteError(w, http.StatusConflict, "PR_NOT_OPEN", "reviewers can only be changed on OPEN PRs")
+		case "reviewer is already assigned to this PR":
+			response.WriteError(w, http.StatusConflict, "ALREADY_ASSIGNED", "reviewer is already assigned to this PR")
+		case "requested reviewer is inactive", "author cannot review own PR":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REVIEWER", err.Error())
+		case "PR changed concurrently":
+			response.WriteError(w, http.StatusConflict, "PR_CHANGED", "PR changed while updating reviewers, try again")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: add reviewer failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+func (h *Handler) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestRemoveReviewerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr, err := h.prSvc.RemoveReviewer(req.PullRequestId, req.UserId)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		case "cannot change reviewers on merged PR":
+			response.WriteError(w, http.StatusConflict, "PR_MERGED", "cannot change reviewers on merged PR")
+		case "PR is not open":
+			response.WriteError(w, http.StatusConflict, "PR_NOT_OPEN", "reviewers can only be changed on OPEN PRs")
+		case "reviewer is not assigned to this PR":
+			response.WriteError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		case "cannot remove the last reviewer":
+			response.WriteError(w, http.StatusConflict, "LAST_REVIEWER", "cannot remove the last reviewer")
+		case "no senior reviewer available":
+			response.WriteError(w, http.StatusConflict, "NO_SENIOR", "cannot remove the only senior reviewer")
+		case "PR changed concurrently":
+			response.WriteError(w, http.StatusConflict, "PR_CHANGED", "PR changed while updating reviewers, try again")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: remove reviewer failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+func (h *Handler) GetPullRequestExplain(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestExplainParams) {
+	if params.PullRequestId == "" {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id parameter is required")
+		return
+	}
+
+	traces, err := h.prSvc.GetAssignmentTraces(params.PullRequestId)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: explain failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pull_request_id": params.PullRequestId, "traces": traces})
+}
+
+func (h *Handler) PostPullRequestRequestReReview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestRequestReReviewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr, err := h.prSvc.RequestReReview(req.PullRequestId)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		case "PR is not open":
+			response.WriteError(w, http.StatusConflict, "PR_NOT_OPEN", "re-review can only be requested on OPEN PRs")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: request re-review failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+func (h *Handler) PostPullRequestUpdateSize(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestUpdateSizeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	opts := api.CreatePROptions{
+		ChangedFiles:   req.ChangedFiles,
+		RequiredSkills: req.RequiredSkills,
+	}
+	if req.SkillMatch != nil {
+		opts.SkillMatch = *req.SkillMatch
+	}
+
+	pr, trace, err := h.prSvc.UpdatePRSize(req.PullRequestId, req.LinesAdded, req.LinesRemoved, req.FilesChanged, opts)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		case "cannot update merged PR":
+			response.WriteError(w, http.StatusConflict, "PR_MERGED", "cannot update size of merged PR")
+		default:
+			writeAssignmentError(w, "update size", err)
+		}
+		return
+	}
+
+	resp := map[string]interface{}{"pr": pr}
+	addShortfall(resp, trace)
+	if explainRequested(r) && trace != nil {
+		resp["trace"] = trace
+	}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) GetPullRequestReviews(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestReviewsParams) {
+	if params.PullRequestId == "" {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id parameter is required")
+		return
+	}
+
+	reviews, err := h.prSvc.GetReviews(params.PullRequestId)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: reviews failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, reviews)
+}
+
+func (h *Handler) GetPullRequestGet(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestGetParams) {
+	if params.PullRequestId == "" {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id parameter is required")
+		return
+	}
+
+	pr, reviews, err := h.prSvc.GetPR(params.PullRequestId)
+	if err != nil {
+		switch err.Error() {
+		case "PR not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: get failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": pr, "reviews": reviews})
+}
+
+func (h *Handler) GetPullRequestList(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestListParams) {
+	query := api.PullRequestListQuery{
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		MergedFrom:  params.MergedFrom,
+		MergedTo:    params.MergedTo,
+		Filter:      api.PullRequestFilter{MinSize: params.MinSize, MaxSize: params.MaxSize},
+	}
+	if params.Status != nil {
+		query.Status = *params.Status
+	}
+	if params.AuthorId != nil {
+		query.AuthorId = *params.AuthorId
+	}
+	if params.ReviewerId != nil {
+		query.ReviewerId = *params.ReviewerId
+	}
+	if params.TeamName != nil {
+		query.TeamName = *params.TeamName
+	}
+	if params.Repository != nil {
+		query.Filter.Repository = *params.Repository
+	}
+	if params.Label != nil {
+		query.Filter.Label = *params.Label
+	}
+	if params.SourceBranch != nil {
+		query.Filter.SourceBranch = *params.SourceBranch
+	}
+	if params.TargetBranch != nil {
+		query.Filter.TargetBranch = *params.TargetBranch
+	}
+	if params.Sort != nil {
+		query.Sort = *params.Sort
+	}
+	if params.Order != nil {
+		query.Order = *params.Order
+	}
+	if params.Limit != nil {
+		if *params.Limit == 0 {
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be between 1 and 100")
+			return
+		}
+		query.Limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+
+	page, err := h.prSvc.ListPRs(query)
+	if err != nil {
+		switch err.Error() {
+		case "unknown PR status":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "status must be DRAFT, OPEN, MERGED or CLOSED")
+		case "unknown sort":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "sort must be created_at, merged_at or size and order asc or desc")
+		case "limit must be between 1 and 100":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be between 1 and 100")
+		case "date range starts after it ends":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "date range starts after it ends")
+		case "invalid cursor":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "cursor is invalid or belongs to another sort")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("pr: list failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, page)
+}
+
+// explainRequested reports whether the request asks for the assignment trace
+// with ?explain=true.
+func explainRequested(r *http.Request) bool {
+	explain, err := strconv.ParseBool(r.URL.Query().Get("explain"))
+	return err == nil && explain
+}
+
+// addShortfall reports the reviewer seats the assignment left empty, and
+// whether open review limits were the reason, as CAPACITY_EXHAUSTED.
+func addShortfall(resp map[string]interface{}, trace *api.AssignmentTrace) {
+	if trace == nil || trace.Shortfall == 0 {
+		return
+	}
+	resp["shortfall"] = trace.Shortfall
+	if trace.CapacityExhausted {
+		resp["capacity_exhausted"] = true
+	}
 }
 
 func (h *Handler) GetStats(w http.ResponseWriter, _ *http.Request) {
@@ -0,0 +1,34 @@
+package pullrequest
+
+import (
+	"testing"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+func TestAddShortfall_Table(t *testing.T) {
+	cases := []struct {
+		name         string
+		trace        *api.AssignmentTrace
+		wantShort    interface{}
+		wantCapacity interface{}
+	}{
+		{"no trace", nil, nil, nil},
+		{"all seats filled", &api.AssignmentTrace{}, nil, nil},
+		{"empty team", &api.AssignmentTrace{Shortfall: 1}, 1, nil},
+		{"capacity exhausted", &api.AssignmentTrace{Shortfall: 2, CapacityExhausted: true}, 2, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := map[string]interface{}{}
+			addShortfall(resp, tc.trace)
+			if resp["shortfall"] != tc.wantShort {
+				t.Fatalf("want shortfall %v got %v", tc.wantShort, resp["shortfall"])
+			}
+			if resp["capacity_exhausted"] != tc.wantCapacity {
+				t.Fatalf("want capacity_exhausted %v got %v", tc.wantCapacity, resp["capacity_exhausted"])
+			}
+		})
+	}
+}
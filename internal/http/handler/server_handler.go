@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/http/handler/codeowners"
 	"github.com/V1merX/pr-reviewer-service/internal/http/handler/pullrequest"
 	"github.com/V1merX/pr-reviewer-service/internal/http/handler/team"
 	"github.com/V1merX/pr-reviewer-service/internal/http/handler/user"
@@ -12,16 +13,18 @@ import (
 )
 
 type ServerHandler struct {
-	team *team.Handler
-	user *user.Handler
-	pr   *pullrequest.Handler
+	team       *team.Handler
+	user       *user.Handler
+	pr         *pullrequest.Handler
+	codeOwners *codeowners.Handler
 }
 
-func NewServerHandler(teamSvc service.TeamService, userSvc service.UserService, prSvc service.PullRequestService) *ServerHandler {
-	t := team.New(teamSvc)
+func NewServerHandler(teamSvc service.TeamService, userSvc service.UserService, prSvc service.PullRequestService, codeOwnersSvc service.CodeOwnersService, adminToken string) *ServerHandler {
+	t := team.New(teamSvc, prSvc)
 	u := user.New(userSvc, prSvc)
-	p := pullrequest.New(prSvc)
-	return &ServerHandler{team: t, user: u, pr: p}
+	p := pullrequest.New(prSvc, adminToken)
+	c := codeowners.New(codeOwnersSvc)
+	return &ServerHandler{team: t, user: u, pr: p, codeOwners: c}
 }
 
 func (h *ServerHandler) RegisterRoutes(router *chi.Mux) {
@@ -36,10 +39,21 @@ func (h *ServerHandler) RegisterRoutes(router *chi.Mux) {
 		router.Route("/team", func(r chi.Router) {
 			r.Post("/add", wrapper.PostTeamAdd)
 			r.Get("/get", wrapper.GetTeamGet)
+			r.Post("/update", wrapper.PostTeamUpdate)
+			r.Get("/pairings", wrapper.GetTeamPairings)
+			r.Post("/rebalance", wrapper.PostTeamRebalance)
 		})
 
 		router.Route("/users", func(r chi.Router) {
 			r.Post("/setIsActive", wrapper.PostUsersSetIsActive)
+			r.Post("/setSkills", wrapper.PostUsersSetSkills)
+			r.Post("/setMaxOpenReviews", wrapper.PostUsersSetMaxOpenReviews)
+			r.Post("/setWorkingHours", wrapper.PostUsersSetWorkingHours)
+			r.Post("/setSeniority", wrapper.PostUsersSetSeniority)
+			r.Post("/addUnavailability", wrapper.PostUsersAddUnavailability)
+			r.Get("/getUnavailability", wrapper.GetUsersGetUnavailability)
+			r.Post("/updateUnavailability", wrapper.PostUsersUpdateUnavailability)
+			r.Post("/deleteUnavailability", wrapper.PostUsersDeleteUnavailability)
 			r.Get("/getReview", wrapper.GetUsersGetReview)
 			r.Post("/deactivateBatch", wrapper.PostUsersDeactivateBatch)
 		})
@@ -48,6 +62,24 @@ func (h *ServerHandler) RegisterRoutes(router *chi.Mux) {
 			r.Post("/create", wrapper.PostPullRequestCreate)
 			r.Post("/merge", wrapper.PostPullRequestMerge)
 			r.Post("/reassign", wrapper.PostPullRequestReassign)
+			r.Post("/preview", wrapper.PostPullRequestPreview)
+			r.Post("/addReviewer", wrapper.PostPullRequestAddReviewer)
+			r.Post("/removeReviewer", wrapper.PostPullRequestRemoveReviewer)
+			r.Post("/review", wrapper.PostPullRequestReview)
+			r.Post("/requestReReview", wrapper.PostPullRequestRequestReReview)
+			r.Get("/reviews", wrapper.GetPullRequestReviews)
+			r.Get("/get", wrapper.GetPullRequestGet)
+			r.Get("/list", wrapper.GetPullRequestList)
+			r.Post("/updateSize", wrapper.PostPullRequestUpdateSize)
+			r.Post("/markReady", wrapper.PostPullRequestMarkReady)
+			r.Post("/close", wrapper.PostPullRequestClose)
+			r.Post("/reopen", wrapper.PostPullRequestReopen)
+			r.Get("/explain", wrapper.GetPullRequestExplain)
+		})
+
+		router.Route("/codeowners", func(r chi.Router) {
+			r.Post("/set", wrapper.PostCodeOwnersSet)
+			r.Get("/get", wrapper.GetCodeOwnersGet)
 		})
 
 		router.Get("/stats", h.pr.GetStats)
@@ -58,14 +90,58 @@ func (h *ServerHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 	h.team.PostTeamAdd(w, r)
 }
 
+func (h *ServerHandler) GetTeamPairings(w http.ResponseWriter, r *http.Request, params api.GetTeamPairingsParams) {
+	h.team.GetTeamPairings(w, r, params)
+}
+
 func (h *ServerHandler) GetTeamGet(w http.ResponseWriter, r *http.Request, params api.GetTeamGetParams) {
 	h.team.GetTeamGet(w, r, params)
 }
 
+func (h *ServerHandler) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
+	h.team.PostTeamUpdate(w, r)
+}
+
+func (h *ServerHandler) PostTeamRebalance(w http.ResponseWriter, r *http.Request) {
+	h.team.PostTeamRebalance(w, r)
+}
+
 func (h *ServerHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 	h.user.PostUsersSetIsActive(w, r)
 }
 
+func (h *ServerHandler) PostUsersSetSkills(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersSetSkills(w, r)
+}
+
+func (h *ServerHandler) PostUsersSetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersSetMaxOpenReviews(w, r)
+}
+
+func (h *ServerHandler) PostUsersSetSeniority(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersSetSeniority(w, r)
+}
+
+func (h *ServerHandler) PostUsersSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersSetWorkingHours(w, r)
+}
+
+func (h *ServerHandler) PostUsersAddUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersAddUnavailability(w, r)
+}
+
+func (h *ServerHandler) GetUsersGetUnavailability(w http.ResponseWriter, r *http.Request, params api.GetUsersGetUnavailabilityParams) {
+	h.user.GetUsersGetUnavailability(w, r, params)
+}
+
+func (h *ServerHandler) PostUsersUpdateUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersUpdateUnavailability(w, r)
+}
+
+func (h *ServerHandler) PostUsersDeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.user.PostUsersDeleteUnavailability(w, r)
+}
+
 func (h *ServerHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	h.pr.PostPullRequestCreate(w, r)
 }
@@ -78,6 +154,58 @@ func (h *ServerHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.R
 	h.pr.PostPullRequestReassign(w, r)
 }
 
+func (h *ServerHandler) PostPullRequestPreview(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestPreview(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestAddReviewer(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestRemoveReviewer(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestReview(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestMarkReady(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestClose(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestReopen(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestRequestReReview(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestRequestReReview(w, r)
+}
+
+func (h *ServerHandler) PostPullRequestUpdateSize(w http.ResponseWriter, r *http.Request) {
+	h.pr.PostPullRequestUpdateSize(w, r)
+}
+
+func (h *ServerHandler) GetPullRequestReviews(w http.ResponseWriter, r *http.Request, params api.GetPullRequestReviewsParams) {
+	h.pr.GetPullRequestReviews(w, r, params)
+}
+
+func (h *ServerHandler) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params api.GetPullRequestGetParams) {
+	h.pr.GetPullRequestGet(w, r, params)
+}
+
+func (h *ServerHandler) GetPullRequestList(w http.ResponseWriter, r *http.Request, params api.GetPullRequestListParams) {
+	h.pr.GetPullRequestList(w, r, params)
+}
+
+func (h *ServerHandler) GetPullRequestExplain(w http.ResponseWriter, r *http.Request, params api.GetPullRequestExplainParams) {
+	h.pr.GetPullRequestExplain(w, r, params)
+}
+
 func (h *ServerHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
 	h.user.GetUsersGetReview(w, r, params)
 }
@@ -89,3 +217,11 @@ func (h *ServerHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 func (h *ServerHandler) PostUsersDeactivateBatch(w http.ResponseWriter, r *http.Request) {
 	h.user.PostUsersDeactivateBatch(w, r)
 }
+
+func (h *ServerHandler) PostCodeOwnersSet(w http.ResponseWriter, r *http.Request) {
+	h.codeOwners.PostCodeOwnersSet(w, r)
+}
+
+func (h *ServerHandler) GetCodeOwnersGet(w http.ResponseWriter, r *http.Request, params api.GetCodeOwnersGetParams) {
+	h.codeOwners.GetCodeOwnersGet(w, r, params)
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type Handler struct {
-	svc service.TeamService
+	svc   service.TeamService
+	prSvc service.PullRequestService
 }
 
-func New(svc service.TeamService) *Handler {
-	return &Handler{svc: svc}
+func New(svc service.TeamService, prSvc service.PullRequestService) *Handler {
+	return &Handler{svc: svc, prSvc: prSvc}
 }
 
 func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
@@ -26,9 +27,32 @@ func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.svc.AddTeam(&req); err != nil {
-		if err.Error() == "team already exists" {
+		switch err.Error() {
+		case "team already exists":
 			response.WriteError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
-		} else {
+		case "unknown assignment strategy":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "unknown assignment_strategy")
+		case "required reviewers must be positive":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "required_reviewers must be positive")
+		case "required approvals must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "required_approvals must not be negative")
+		case "invalid size thresholds":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "size_thresholds need distinct non-negative min_lines and positive reviewers")
+		case "invalid fallback teams":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "fallback_teams must be distinct existing teams")
+		case "max open reviews must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "max_open_reviews must not be negative")
+		case "unknown pairing mode":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pairing_mode must be ignore or avoid_recent")
+		case "pairing lookback days must be positive":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pairing_lookback_days must be positive")
+		case "unknown seniority":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "seniority must be junior, mid, senior or lead")
+		case "unknown working hours mode":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "working_hours_mode must be ignore or prefer")
+		case "working hours lookahead must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "working_hours_lookahead must not be negative")
+		default:
 			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
 			slog.Error("team: add failed", "error", err)
 		}
@@ -53,3 +77,90 @@ func (h *Handler) GetTeamGet(w http.ResponseWriter, _ *http.Request, params api.
 	}
 	response.WriteJSON(w, http.StatusOK, team)
 }
+
+func (h *Handler) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamUpdateJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	team, err := h.svc.UpdateTeamSettings(req)
+	if err != nil {
+		switch err.Error() {
+		case "team not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		case "unknown assignment strategy":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "unknown assignment_strategy")
+		case "required reviewers must be positive":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "required_reviewers must be positive")
+		case "required approvals must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "required_approvals must not be negative")
+		case "invalid size thresholds":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "size_thresholds need distinct non-negative min_lines and positive reviewers")
+		case "invalid fallback teams":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "fallback_teams must be distinct existing teams")
+		case "max open reviews must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "max_open_reviews must not be negative")
+		case "unknown pairing mode":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pairing_mode must be ignore or avoid_recent")
+		case "pairing lookback days must be positive":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pairing_lookback_days must be positive")
+		case "unknown working hours mode":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "working_hours_mode must be ignore or prefer")
+		case "working hours lookahead must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "working_hours_lookahead must not be negative")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("team: update failed", "error", err)
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handler) GetTeamPairings(w http.ResponseWriter, _ *http.Request, params api.GetTeamPairingsParams) {
+	if params.TeamName == "" {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name parameter is required")
+		return
+	}
+
+	matrix, err := h.prSvc.GetPairingMatrix(params.TeamName, params.LookbackDays)
+	if err != nil {
+		switch err.Error() {
+		case "team not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		case "lookback days must be positive":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "lookback_days must be positive")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("team: pairings failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, matrix)
+}
+
+func (h *Handler) PostTeamRebalance(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamRebalanceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	plan, err := h.prSvc.RebalanceTeam(req.TeamName, req.Apply)
+	if err != nil {
+		switch err.Error() {
+		case "team not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		case "PR changed concurrently":
+			response.WriteError(w, http.StatusConflict, "PR_CHANGED", "A PR changed while rebalancing, try again")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("team: rebalance failed", "error", err)
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, plan)
+}
@@ -20,18 +20,134 @@ func New(userSvc service.UserService, prSvc service.PullRequestService) *Handler
 }
 
 func (h *Handler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		UserID   string `json:"user_id"`
-		IsActive bool   `json:"is_active"`
-	}
+	var req api.PostUsersSetIsActiveJSONBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
 		return
 	}
 
-	user, err := h.userSvc.SetUserStatus(req.UserID, req.IsActive)
+	if req.IsActive {
+		user, err := h.userSvc.SetUserStatus(req.UserId, true)
+		if err != nil {
+			writeUserStatusError(w, err)
+			return
+		}
+		resp := map[string]interface{}{"user": user}
+		response.WriteJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	deactivation, err := h.prSvc.DeactivateUser(req.UserId, req.ReassignReviews)
 	if err != nil {
+		writeUserStatusError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, deactivation)
+}
+
+func writeUserStatusError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "user not found":
 		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		slog.Error("user: set status failed", "error", err)
+	}
+}
+
+func (h *Handler) PostUsersSetSkills(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersSetSkillsJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user, err := h.userSvc.SetUserSkills(req.UserId, req.Skills)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+		case "invalid skill":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "skills must be non-empty tags without spaces")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("user: set skills failed", "error", err)
+		}
+		return
+	}
+
+	resp := map[string]interface{}{"user": user}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostUsersSetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersSetMaxOpenReviewsJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user, err := h.userSvc.SetMaxOpenReviews(req.UserId, req.MaxOpenReviews)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+		case "max open reviews must not be negative":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "max_open_reviews must not be negative")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("user: set max open reviews failed", "error", err)
+		}
+		return
+	}
+
+	resp := map[string]interface{}{"user": user}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostUsersSetSeniority(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersSetSeniorityJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user, err := h.userSvc.SetSeniority(req.UserId, req.Seniority)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+		case "unknown seniority":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "seniority must be junior, mid, senior or lead")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("user: set seniority failed", "error", err)
+		}
+		return
+	}
+
+	resp := map[string]interface{}{"user": user}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostUsersSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersSetWorkingHoursJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user, err := h.userSvc.SetWorkingHours(req.UserId, req.WorkingHours)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+		case "invalid working hours":
+			response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "working_hours needs an IANA timezone and distinct HH:MM start and end")
+		default:
+			response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			slog.Error("user: set working hours failed", "error", err)
+		}
 		return
 	}
 
@@ -46,7 +162,21 @@ func (h *Handler) GetUsersGetReview(w http.ResponseWriter, _ *http.Request, para
 		return
 	}
 
-	prs, err := h.prSvc.FindPRsByReviewer(userID)
+	filter := api.PullRequestFilter{MinSize: params.MinSize, MaxSize: params.MaxSize}
+	if params.Repository != nil {
+		filter.Repository = *params.Repository
+	}
+	if params.Label != nil {
+		filter.Label = *params.Label
+	}
+	if params.SourceBranch != nil {
+		filter.SourceBranch = *params.SourceBranch
+	}
+	if params.TargetBranch != nil {
+		filter.TargetBranch = *params.TargetBranch
+	}
+
+	prs, err := h.prSvc.FindPRsByReviewer(userID, filter)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
 		slog.Error("user: get review failed", "error", err)
@@ -60,6 +190,9 @@ func (h *Handler) GetUsersGetReview(w http.ResponseWriter, _ *http.Request, para
 			PullRequestName: pr.PullRequestName,
 			AuthorId:        pr.AuthorId,
 			Status:          api.PullRequestShortStatus(pr.Status),
+			Repository:      pr.Repository,
+			Url:             pr.Url,
+			Labels:          pr.Labels,
 		})
 	}
 
@@ -92,3 +225,90 @@ func (h *Handler) PostUsersDeactivateBatch(w http.ResponseWriter, r *http.Reques
 	slog.Info("batch deactivation completed", "team", req.TeamName, "deactivated", result.DeactivatedCount, "reassigned", result.ReassignedCount)
 	response.WriteJSON(w, http.StatusOK, result)
 }
+
+func (h *Handler) PostUsersAddUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersAddUnavailabilityJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	unavailability, err := h.userSvc.AddUnavailability(req)
+	if err != nil {
+		writeUnavailabilityError(w, err)
+		return
+	}
+
+	// The range is stored at this point, so a failed handover is reported
+	// alongside it rather than as an error.
+	resp := map[string]interface{}{"unavailability": unavailability}
+	if req.ReassignReviews {
+		reassigned, err := h.prSvc.HandOverReviews(*unavailability)
+		switch {
+		case err != nil:
+			slog.Error("user: hand over reviews failed", "error", err)
+			resp["reassign_error"] = "open reviews could not be listed"
+		case reassigned != nil:
+			resp["reassigned"] = reassigned
+		}
+	}
+	response.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) GetUsersGetUnavailability(w http.ResponseWriter, _ *http.Request, params api.GetUsersGetUnavailabilityParams) {
+	ranges, err := h.userSvc.GetUnavailability(params.UserId)
+	if err != nil {
+		writeUnavailabilityError(w, err)
+		return
+	}
+
+	resp := map[string]interface{}{"user_id": params.UserId, "unavailability": ranges}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostUsersUpdateUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersUpdateUnavailabilityJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	unavailability, err := h.userSvc.UpdateUnavailability(req)
+	if err != nil {
+		writeUnavailabilityError(w, err)
+		return
+	}
+
+	resp := map[string]interface{}{"unavailability": unavailability}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostUsersDeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersDeleteUnavailabilityJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	if err := h.userSvc.DeleteUnavailability(req.Id); err != nil {
+		writeUnavailabilityError(w, err)
+		return
+	}
+
+	resp := map[string]interface{}{"id": req.Id}
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+func writeUnavailabilityError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "user not found":
+		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+	case "unavailability not found":
+		response.WriteError(w, http.StatusNotFound, "NOT_FOUND", "Unavailability not found")
+	case "invalid date range":
+		response.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "start_date and end_date must be YYYY-MM-DD with end_date not before start_date")
+	default:
+		response.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		slog.Error("user: unavailability request failed", "error", err)
+	}
+}
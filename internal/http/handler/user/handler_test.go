@@ -3,6 +3,7 @@ package user
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
 	psvc "github.com/V1merX/pr-reviewer-service/internal/service/pullrequest"
+	usvc "github.com/V1merX/pr-reviewer-service/internal/service/user"
 )
 
 // Заглушки для сервисов
@@ -24,37 +26,134 @@ func (f *fakeUserSvc) SetUserStatus(userID string, status bool) (*api.User, erro
 	return &api.User{UserId: userID, IsActive: status}, nil
 }
 
+func (f *fakeUserSvc) SetMaxOpenReviews(userID string, limit *int) (*api.User, error) {
+	return &api.User{UserId: userID, MaxOpenReviews: limit}, nil
+}
+
+func (f *fakeUserSvc) SetWorkingHours(userID string, hours *api.WorkingHours) (*api.User, error) {
+	return &api.User{UserId: userID, WorkingHours: hours}, nil
+}
+
+func (f *fakeUserSvc) SetSeniority(userID string, seniority api.Seniority) (*api.User, error) {
+	return &api.User{UserId: userID, Seniority: seniority}, nil
+}
+
+func (f *fakeUserSvc) SetUserSkills(userID string, skills []string) (*api.User, error) {
+	return &api.User{UserId: userID, Skills: skills}, nil
+}
+
+func (f *fakeUserSvc) AddUnavailability(req api.PostUsersAddUnavailabilityJSONBody) (*api.Unavailability, error) {
+	if req.StartDate == "" {
+		return nil, usvc.ErrInvalidDateRange
+	}
+	return &api.Unavailability{Id: 1, UserId: req.UserId, StartDate: req.StartDate, EndDate: req.EndDate}, nil
+}
+
+func (f *fakeUserSvc) GetUnavailability(userID string) ([]api.Unavailability, error) {
+	return nil, nil
+}
+
+func (f *fakeUserSvc) UpdateUnavailability(req api.PostUsersUpdateUnavailabilityJSONBody) (*api.Unavailability, error) {
+	return nil, nil
+}
+
+func (f *fakeUserSvc) DeleteUnavailability(id int64) error {
+	return nil
+}
+
 type fakePRSvc struct {
 	prs              map[string][]api.PullRequest
 	deactivateResult *api.BatchDeactivateResponse
 	deactivateErr    error
+	reassignedUsers  []string
+	reviewFilter     api.PullRequestFilter
+	handoverErr      error
+	deactivatedUsers []string
+	reassignError    string
 }
 
 func (f *fakePRSvc) GetActiveTeamMembers(authorID string) ([]api.TeamMember, error) {
 	return nil, nil
 }
 
-func (f *fakePRSvc) SelectRandomReviewers(members []api.TeamMember, count int) []string {
+func (f *fakePRSvc) SelectRandomReviewers(members []api.TeamMember, count int, requireSenior bool) []string {
 	return nil
 }
 
-func (f *fakePRSvc) CreatePR(pr *api.PullRequest) error {
+func (f *fakePRSvc) SelectLeastLoadedReviewers(members []api.TeamMember, openReviews map[string]int, count int) []string {
 	return nil
 }
 
+func (f *fakePRSvc) CreatePR(pr *api.PullRequest, opts api.CreatePROptions) (*api.AssignmentTrace, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) PreviewPR(pr *api.PullRequest, opts api.CreatePROptions) (*api.AssignmentPreview, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) GetPR(prID string) (*api.PullRequest, *api.PullRequestReviews, error) {
+	return nil, nil, nil
+}
+
 func (f *fakePRSvc) FindPRByID(prID string) (*api.PullRequest, error) {
 	return nil, nil
 }
 
-func (f *fakePRSvc) MergePR(prID string) (*api.PullRequest, error) {
+func (f *fakePRSvc) MergePR(prID string, force bool) (*api.PullRequest, error) {
 	return nil, nil
 }
 
-func (f *fakePRSvc) ReassignReviewer(prID, oldReviewerID string) (*api.PullRequest, *string, error) {
+func (f *fakePRSvc) SubmitReview(prID string, userID string, verdict api.ReviewVerdict) (*api.Review, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) RequestReReview(prID string) (*api.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) GetReviews(prID string) (*api.PullRequestReviews, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) UpdatePRSize(prID string, linesAdded, linesRemoved int, filesChanged *int, opts api.CreatePROptions) (*api.PullRequest, *api.AssignmentTrace, error) {
 	return nil, nil, nil
 }
 
-func (f *fakePRSvc) FindPRsByReviewer(userID string) ([]api.PullRequest, error) {
+func (f *fakePRSvc) ListPRs(query api.PullRequestListQuery) (*api.PullRequestPage, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) MarkReady(prID string, opts api.CreatePROptions) (*api.PullRequest, *api.AssignmentTrace, error) {
+	return nil, nil, nil
+}
+
+func (f *fakePRSvc) ClosePR(prID string) (*api.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) ReopenPR(prID string) (*api.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) ReassignReviewer(prID, oldReviewerID string, opts api.ReassignOptions) (*api.PullRequest, *string, *api.AssignmentTrace, error) {
+	return nil, nil, nil, nil
+}
+
+func (f *fakePRSvc) AddReviewer(prID, userID string) (*api.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) RemoveReviewer(prID, userID string) (*api.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) GetAssignmentTraces(prID string) ([]api.AssignmentTrace, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) FindPRsByReviewer(userID string, filter api.PullRequestFilter) ([]api.PullRequest, error) {
+	f.reviewFilter = filter
 	return f.prs[userID], nil
 }
 
@@ -66,6 +165,37 @@ func (f *fakePRSvc) DeactivateUsersAndReassignPRs(teamName string, userIDs []str
 	return f.deactivateResult, f.deactivateErr
 }
 
+func (f *fakePRSvc) RebalanceTeam(teamName string, apply bool) (*api.TeamRebalance, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) GetPairingMatrix(teamName string, lookbackDays *int) (*api.PairingMatrix, error) {
+	return nil, nil
+}
+
+func (f *fakePRSvc) ReassignOpenReviews(userID string) ([]api.ReviewReassignment, error) {
+	f.reassignedUsers = append(f.reassignedUsers, userID)
+	return nil, nil
+}
+
+func (f *fakePRSvc) DeactivateUser(userID string, reassignReviews bool) (*api.UserDeactivation, error) {
+	f.deactivatedUsers = append(f.deactivatedUsers, userID)
+	result := &api.UserDeactivation{User: &api.User{UserId: userID}}
+	if reassignReviews {
+		f.reassignedUsers = append(f.reassignedUsers, userID)
+		result.ReassignError = f.reassignError
+	}
+	return result, nil
+}
+
+func (f *fakePRSvc) HandOverReviews(unavailability api.Unavailability) ([]api.ReviewReassignment, error) {
+	f.reassignedUsers = append(f.reassignedUsers, unavailability.UserId)
+	if f.handoverErr != nil {
+		return nil, f.handoverErr
+	}
+	return []api.ReviewReassignment{{PullRequestId: "pr1", Error: "no active replacement candidate in team"}}, nil
+}
+
 func TestGetUsersGetReview_Table(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	prsvc := &fakePRSvc{prs: map[string][]api.PullRequest{"u1": {{PullRequestId: "p1", PullRequestName: "P1", AuthorId: "a1", Status: api.PullRequestStatusOPEN}}}}
@@ -96,6 +226,31 @@ func TestGetUsersGetReview_Table(t *testing.T) {
 	_ = logger
 }
 
+func TestGetUsersGetReview_Filters(t *testing.T) {
+	prsvc := &fakePRSvc{prs: map[string][]api.PullRequest{"u1": {
+		{PullRequestId: "p1", Repository: "org/api", Labels: []string{"bug"}, Status: api.PullRequestStatusOPEN},
+	}}}
+	h := New(&fakeUserSvc{}, prsvc)
+
+	label := "bug"
+	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&label=bug", nil)
+	w := httptest.NewRecorder()
+	h.GetUsersGetReview(w, req, api.GetUsersGetReviewParams{UserId: "u1", Label: &label})
+
+	var resp struct {
+		PullRequests []api.PullRequestShort `json:"pull_requests"`
+	}
+	if err := json.NewDecoder(w.Result().Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if prsvc.reviewFilter.Label != "bug" {
+		t.Fatalf("expected the label filter to be passed on, got %+v", prsvc.reviewFilter)
+	}
+	if len(resp.PullRequests) != 1 || resp.PullRequests[0].PullRequestId != "p1" || resp.PullRequests[0].Repository != "org/api" {
+		t.Fatalf("unexpected pull requests %+v", resp.PullRequests)
+	}
+}
+
 func TestPostUsersDeactivateBatch_Table(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	prsvc := &fakePRSvc{deactivateResult: &api.BatchDeactivateResponse{DeactivatedCount: 1, ReassignedCount: 1}}
@@ -120,3 +275,90 @@ func TestPostUsersDeactivateBatch_Table(t *testing.T) {
 	}
 	_ = logger
 }
+
+func TestPostUsersAddUnavailability_Table(t *testing.T) {
+	cases := []struct {
+		name         string
+		body         api.PostUsersAddUnavailabilityJSONBody
+		handoverErr  error
+		wantStatus   int
+		wantReassign bool
+		wantKey      string
+	}{
+		{"invalid range", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", EndDate: "2025-01-10"}, nil, http.StatusBadRequest, false, ""},
+		{"range only", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", StartDate: "2025-01-01", EndDate: "2025-01-10"}, nil, http.StatusCreated, false, "unavailability"},
+		{"range with reassign", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", StartDate: "2025-01-01", EndDate: "2025-01-10", ReassignReviews: true}, nil, http.StatusCreated, true, "reassigned"},
+		{"failed handover keeps the range", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", StartDate: "2025-01-01", EndDate: "2025-01-10", ReassignReviews: true}, errors.New("db down"), http.StatusCreated, true, "reassign_error"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prsvc := &fakePRSvc{handoverErr: tc.handoverErr}
+			h := New(&fakeUserSvc{}, prsvc)
+
+			b, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/users/addUnavailability", bytes.NewReader(b))
+			w := httptest.NewRecorder()
+			h.PostUsersAddUnavailability(w, req)
+			if w.Result().StatusCode != tc.wantStatus {
+				t.Fatalf("want status %d got %d", tc.wantStatus, w.Result().StatusCode)
+			}
+			if reassigned := len(prsvc.reassignedUsers) == 1; reassigned != tc.wantReassign {
+				t.Fatalf("want reassign %v got %v", tc.wantReassign, prsvc.reassignedUsers)
+			}
+			if tc.wantKey != "" {
+				var body map[string]json.RawMessage
+				if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if _, ok := body[tc.wantKey]; !ok {
+					t.Fatalf("want %q in response, got %v", tc.wantKey, body)
+				}
+			}
+		})
+	}
+}
+
+func TestPostUsersSetIsActive_Table(t *testing.T) {
+	cases := []struct {
+		name           string
+		body           api.PostUsersSetIsActiveJSONBody
+		reassignError  string
+		wantDeactivate bool
+		wantReassign   bool
+		wantKey        string
+	}{
+		{"deactivate only", api.PostUsersSetIsActiveJSONBody{UserId: "u1"}, "", true, false, "user"},
+		{"deactivate with reassign", api.PostUsersSetIsActiveJSONBody{UserId: "u1", ReassignReviews: true}, "", true, true, "user"},
+		{"listing failure keeps deactivation", api.PostUsersSetIsActiveJSONBody{UserId: "u1", ReassignReviews: true}, "open reviews could not be listed", true, true, "reassign_error"},
+		{"activate ignores reassign", api.PostUsersSetIsActiveJSONBody{UserId: "u1", IsActive: true, ReassignReviews: true}, "", false, false, "user"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prsvc := &fakePRSvc{reassignError: tc.reassignError}
+			h := New(&fakeUserSvc{}, prsvc)
+
+			b, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewReader(b))
+			w := httptest.NewRecorder()
+			h.PostUsersSetIsActive(w, req)
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("want status 200 got %d", w.Result().StatusCode)
+			}
+			if deactivated := len(prsvc.deactivatedUsers) == 1; deactivated != tc.wantDeactivate {
+				t.Fatalf("want deactivate %v got %v", tc.wantDeactivate, prsvc.deactivatedUsers)
+			}
+			if reassigned := len(prsvc.reassignedUsers) == 1; reassigned != tc.wantReassign {
+				t.Fatalf("want reassign %v got %v", tc.wantReassign, prsvc.reassignedUsers)
+			}
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if _, ok := body[tc.wantKey]; !ok {
+				t.Fatalf("want %q in response, got %v", tc.wantKey, body)
+			}
+		})
+	}
+}
@@ -19,12 +19,12 @@ type Server struct {
 	Handler *handler.ServerHandler
 }
 
-func New(config *config.Config, logger *slog.Logger, teamService service.TeamService, userService service.UserService, prService service.PullRequestService) *Server {
+func New(config *config.Config, logger *slog.Logger, teamService service.TeamService, userService service.UserService, prService service.PullRequestService, codeOwnersService service.CodeOwnersService) *Server {
 	return &Server{
 		Config:  config,
 		Router:  chi.NewRouter(),
 		Logger:  logger,
-		Handler: handler.NewServerHandler(teamService, userService, prService),
+		Handler: handler.NewServerHandler(teamService, userService, prService, codeOwnersService, config.Server.AdminToken),
 	}
 }
 
@@ -1,18 +1,73 @@
 package models
 
-import "time"
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
 
 type User struct {
-	UserId   string `db:"user_id"`
-	Username string `db:"username"`
-	TeamName string `db:"team_name"`
-	IsActive bool   `db:"is_active"`
+	UserId         string `db:"user_id"`
+	Username       string `db:"username"`
+	TeamName       string `db:"team_name"`
+	IsActive       bool   `db:"is_active"`
+	MaxOpenReviews *int   `db:"max_open_reviews"`
+	Seniority      string `db:"seniority"`
+}
+
+type UserWorkingHours struct {
+	UserId    string  `db:"user_id"`
+	Timezone  *string `db:"timezone"`
+	WorkStart *string `db:"work_start"`
+	WorkEnd   *string `db:"work_end"`
+}
+
+type Unavailability struct {
+	Id        int64  `db:"id"`
+	UserId    string `db:"user_id"`
+	StartDate string `db:"starts_on"`
+	EndDate   string `db:"ends_on"`
+	Reason    string `db:"reason"`
+}
+
+type UserSkill struct {
+	UserId string `db:"user_id"`
+	Skill  string `db:"skill"`
+}
+
+type Team struct {
+	TeamName           string `db:"team_name"`
+	AssignmentStrategy string `db:"assignment_strategy"`
+	RequiredReviewers  int    `db:"required_reviewers"`
+	MaxOpenReviews     *int   `db:"max_open_reviews"`
+	RequireSenior      bool   `db:"require_senior"`
+	RequiredApprovals  int    `db:"required_approvals"`
+
+	WorkingHoursMode      string `db:"working_hours_mode"`
+	WorkingHoursLookahead int    `db:"working_hours_lookahead"`
+
+	PairingMode         string `db:"pairing_mode"`
+	PairingLookbackDays int    `db:"pairing_lookback_days"`
+}
+
+type SizeThreshold struct {
+	MinLines  int `db:"min_lines"`
+	Reviewers int `db:"reviewers"`
 }
 
 type TeamMember struct {
-	UserId   string `db:"user_id"`
-	Username string `db:"username"`
-	IsActive bool   `db:"is_active"`
+	UserId         string `db:"user_id"`
+	Username       string `db:"username"`
+	IsActive       bool   `db:"is_active"`
+	MaxOpenReviews *int   `db:"max_open_reviews"`
+	Seniority      string `db:"seniority"`
+}
+
+type Pairing struct {
+	AuthorId   string `db:"author_id"`
+	ReviewerId string `db:"reviewer_id"`
+	Reviews    int    `db:"reviews"`
 }
 
 type PullRequest struct {
@@ -22,4 +77,39 @@ type PullRequest struct {
 	Status          string     `db:"status"`
 	CreatedAt       time.Time  `db:"created_at"`
 	MergedAt        *time.Time `db:"merged_at"`
+	ReviewRound     int        `db:"review_round"`
+
+	Repository   string         `db:"repository"`
+	Url          string         `db:"url"`
+	SourceBranch string         `db:"source_branch"`
+	TargetBranch string         `db:"target_branch"`
+	Labels       pq.StringArray `db:"labels"`
+	LinesAdded   int            `db:"lines_added"`
+	LinesRemoved int            `db:"lines_removed"`
+	FilesChanged int            `db:"files_changed"`
+}
+
+type Reviewer struct {
+	UserId         string         `db:"user_id"`
+	TeamName       sql.NullString `db:"team_name"`
+	AssignmentSeed sql.NullInt64  `db:"assignment_seed"`
+	Source         string         `db:"source"`
+}
+
+type Review struct {
+	PullRequestId string    `db:"pull_request_id"`
+	Round         int       `db:"round"`
+	UserId        string    `db:"user_id"`
+	Verdict       string    `db:"verdict"`
+	SubmittedAt   time.Time `db:"submitted_at"`
+}
+
+type ReviewLoad struct {
+	UserId      string `db:"user_id"`
+	OpenReviews int    `db:"open_reviews"`
+}
+
+type CodeOwnersRule struct {
+	Pattern string         `db:"pattern"`
+	Owners  pq.StringArray `db:"owners"`
 }
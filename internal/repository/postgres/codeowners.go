@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/repository/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type CodeOwnersRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewCodeOwnersRepository(db *sqlx.DB, logger *slog.Logger) *CodeOwnersRepository {
+	return &CodeOwnersRepository{
+		db:  db,
+		log: logger,
+	}
+}
+
+const (
+	qDeleteCodeOwnersRules = `DELETE FROM codeowners_rules WHERE repository = $1`
+	qInsertCodeOwnersRule  = `INSERT INTO codeowners_rules (repository, position, pattern, owners) VALUES ($1, $2, $3, $4)`
+	qSelectCodeOwnersRules = `SELECT pattern, owners FROM codeowners_rules WHERE repository = $1 ORDER BY position`
+)
+
+func (r *CodeOwnersRepository) withTx(fn func(*sqlx.Tx) error) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("db: begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			fmt.Printf("tx rollback error: %v\n", err)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit: %w", err)
+	}
+	return nil
+}
+
+func (r *CodeOwnersRepository) ReplaceRules(repo string, rules []api.CodeOwnersRule) error {
+	err := r.withTx(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(qDeleteCodeOwnersRules, repo); err != nil {
+			return fmt.Errorf("db: delete codeowners rules: %w", err)
+		}
+
+		for i, rule := range rules {
+			if _, err := tx.Exec(qInsertCodeOwnersRule, repo, i, rule.Pattern, pq.Array(rule.Owners)); err != nil {
+				return fmt.Errorf("db: insert codeowners rule: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("ReplaceRules failed", "repository", repo, "err", err)
+		return err
+	}
+	r.log.Info("ReplaceRules succeeded", "repository", repo, "rules", len(rules))
+	return nil
+}
+
+func (r *CodeOwnersRepository) FindRules(repo string) ([]api.CodeOwnersRule, error) {
+	var dbRules []models.CodeOwnersRule
+	if err := r.db.Select(&dbRules, qSelectCodeOwnersRules, repo); err != nil {
+		return nil, fmt.Errorf("db: select codeowners rules: %w", err)
+	}
+
+	rules := make([]api.CodeOwnersRule, 0, len(dbRules))
+	for _, rule := range dbRules {
+		rules = append(rules, api.CodeOwnersRule{
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		})
+	}
+	return rules, nil
+}
@@ -2,13 +2,19 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/repository"
+	"github.com/V1merX/pr-reviewer-service/internal/repository/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type PullRequestRepository struct {
@@ -24,10 +30,25 @@ func NewPullRequestRepository(db *sqlx.DB, logger *slog.Logger) *PullRequestRepo
 }
 
 const (
-	qSelectPRByID        = `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM pull_requests WHERE pull_request_id = $1`
-	qSelectAllPRs        = `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM pull_requests ORDER BY created_at DESC`
-	qSelectPRsByReviewer = `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at FROM pull_requests pr WHERE pr.pull_request_id IN (SELECT pull_request_id FROM pr_reviewers WHERE user_id = $1) ORDER BY pr.created_at DESC`
-	qSelectReviewers     = `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`
+	qSelectPRByID        = `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, review_round, repository, url, source_branch, target_branch, labels, lines_added, lines_removed, files_changed FROM pull_requests WHERE pull_request_id = $1`
+	qSelectAllPRs        = `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, review_round, repository, url, source_branch, target_branch, labels, lines_added, lines_removed, files_changed FROM pull_requests ORDER BY created_at DESC`
+	qSelectPRColumns     = `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.review_round, pr.repository, pr.url, pr.source_branch, pr.target_branch, pr.labels, pr.lines_added, pr.lines_removed, pr.files_changed FROM pull_requests pr`
+	qSelectOpenTeamPRs   = `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.review_round, pr.repository, pr.url, pr.source_branch, pr.target_branch, pr.labels, pr.lines_added, pr.lines_removed, pr.files_changed FROM pull_requests pr WHERE pr.status = 'OPEN' AND EXISTS (SELECT 1 FROM pr_reviewers r JOIN users u ON u.user_id = r.user_id WHERE r.pull_request_id = pr.pull_request_id AND u.team_name = $1) ORDER BY pr.created_at DESC, pr.pull_request_id`
+	qLockPR              = `SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE`
+	qSelectReviewerIDs   = `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`
+	qDeleteReviewer      = `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+	qMoveReviewer        = `UPDATE pr_reviewers SET user_id = $3, team_name = $4, assignment_seed = NULL WHERE pull_request_id = $1 AND user_id = $2`
+	qInsertPR            = `INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, review_round, repository, url, source_branch, target_branch, labels, lines_added, lines_removed, files_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	qUpdatePR            = `UPDATE pull_requests SET status = $1, merged_at = $2, review_round = $3, repository = $4, url = $5, source_branch = $6, target_branch = $7, labels = $8, lines_added = $9, lines_removed = $10, files_changed = $11 WHERE pull_request_id = $12`
+	qSelectReviewers     = `SELECT user_id, team_name, assignment_seed, source FROM pr_reviewers WHERE pull_request_id = $1`
+	qInsertReviewer      = `INSERT INTO pr_reviewers (pull_request_id, user_id, team_name, assignment_seed, source) VALUES ($1, $2, $3, $4, $5)`
+	qCountAuthorPairings = `SELECT r.user_id AS reviewer_id, COUNT(*) AS reviews FROM pr_reviewers r JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id WHERE pr.author_id = $1 AND pr.created_at >= $2 GROUP BY r.user_id`
+	qSelectTeamPairings  = `SELECT pr.author_id, r.user_id AS reviewer_id, COUNT(*) AS reviews FROM pr_reviewers r JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id JOIN users u ON u.user_id = pr.author_id WHERE u.team_name = $1 AND pr.created_at >= $2 GROUP BY pr.author_id, r.user_id`
+	qInsertTrace         = `INSERT INTO assignment_traces (pull_request_id, action, trace) VALUES ($1, $2, $3)`
+	qSelectTraces        = `SELECT trace FROM assignment_traces WHERE pull_request_id = $1 ORDER BY id`
+	qUpsertReview        = `INSERT INTO pr_reviews (pull_request_id, round, user_id, verdict, submitted_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (pull_request_id, round, user_id) DO UPDATE SET verdict = EXCLUDED.verdict, submitted_at = EXCLUDED.submitted_at`
+	qSelectReviews       = `SELECT pull_request_id, round, user_id, verdict, submitted_at FROM pr_reviews WHERE pull_request_id = $1 ORDER BY round, submitted_at, user_id`
+	qCountOpenReviews    = `SELECT u.user_id, COUNT(pr.pull_request_id) AS open_reviews FROM users u LEFT JOIN pr_reviewers r ON r.user_id = u.user_id LEFT JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id AND pr.status = 'OPEN' WHERE u.team_name = $1 GROUP BY u.user_id`
 )
 
 func (r *PullRequestRepository) withTx(fn func(*sqlx.Tx) error) error {
@@ -51,23 +72,25 @@ func (r *PullRequestRepository) withTx(fn func(*sqlx.Tx) error) error {
 	return nil
 }
 
-func (r *PullRequestRepository) CreatePR(pr api.PullRequest) error {
+// CreatePR stores a new PR with its reviewers and, when given, the trace of
+// its assignment in one transaction.
+func (r *PullRequestRepository) CreatePR(pr api.PullRequest, trace *api.AssignmentTrace) error {
 	createdAt := time.Now()
 	if pr.CreatedAt != nil {
 		createdAt = *pr.CreatedAt
 	}
+	round := max(pr.ReviewRound, 1)
 
 	err := r.withTx(func(tx *sqlx.Tx) error {
-		if _, err := tx.Exec(`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at) VALUES ($1, $2, $3, $4, $5)`, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, createdAt); err != nil {
+		if _, err := tx.Exec(qInsertPR, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, createdAt, round,
+			pr.Repository, pr.Url, pr.SourceBranch, pr.TargetBranch, labels(pr), pr.LinesAdded, pr.LinesRemoved, pr.FilesChanged); err != nil {
 			return fmt.Errorf("insert pull_request: %w", err)
 		}
 
-		for _, reviewer := range pr.AssignedReviewers {
-			if _, err := tx.Exec(`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`, pr.PullRequestId, reviewer); err != nil {
-				return fmt.Errorf("insert reviewer: %w", err)
-			}
+		if err := insertReviewers(tx, pr); err != nil {
+			return err
 		}
-		return nil
+		return insertTrace(tx, trace)
 	})
 	if err != nil {
 		r.log.Error("CreatePR failed", "pr_id", pr.PullRequestId, "err", err)
@@ -77,12 +100,48 @@ func (r *PullRequestRepository) CreatePR(pr api.PullRequest) error {
 	return nil
 }
 
+// insertReviewers writes pr.AssignedReviewers, taking the source team, the
+// assignment seed and the source of each reviewer from pr.Reviewers when they
+// are known. Reviewers without a known source count as automatic.
+func insertReviewers(tx *sqlx.Tx, pr api.PullRequest) error {
+	known := make(map[string]api.ReviewerAssignment, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		known[reviewer.UserId] = reviewer
+	}
+
+	for _, reviewer := range pr.AssignedReviewers {
+		teamName := sql.NullString{String: known[reviewer].TeamName, Valid: known[reviewer].TeamName != ""}
+		var seed sql.NullInt64
+		if s := known[reviewer].AssignmentSeed; s != nil {
+			seed = sql.NullInt64{Int64: *s, Valid: true}
+		}
+		source := known[reviewer].Source
+		if source == "" {
+			source = api.ReviewerSourceAuto
+		}
+		if _, err := tx.Exec(qInsertReviewer, pr.PullRequestId, reviewer, teamName, seed, source); err != nil {
+			return fmt.Errorf("insert reviewer: %w", err)
+		}
+	}
+	return nil
+}
+
+// labels returns the PR's labels as a Postgres array; no labels is an empty
+// array rather than NULL.
+func labels(pr api.PullRequest) pq.StringArray {
+	if pr.Labels == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(pr.Labels)
+}
+
 func (r *PullRequestRepository) scanRowToPR(scanner interface{ Scan(dest ...any) error }) (api.PullRequest, error) {
 	var pr api.PullRequest
 	var createdAt time.Time
 	var mergedAt *time.Time
 
-	if err := scanner.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt); err != nil {
+	if err := scanner.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt, &pr.ReviewRound,
+		&pr.Repository, &pr.Url, &pr.SourceBranch, &pr.TargetBranch, (*pq.StringArray)(&pr.Labels), &pr.LinesAdded, &pr.LinesRemoved, &pr.FilesChanged); err != nil {
 		r.log.Error("scanRowToPR: scan failed", "err", err)
 		return api.PullRequest{}, fmt.Errorf("scan pr: %w", err)
 	}
@@ -90,12 +149,25 @@ func (r *PullRequestRepository) scanRowToPR(scanner interface{ Scan(dest ...any)
 	pr.CreatedAt = &createdAt
 	pr.MergedAt = mergedAt
 
-	reviewers := []string{}
+	var reviewers []models.Reviewer
 	if err := r.db.Select(&reviewers, qSelectReviewers, pr.PullRequestId); err != nil {
 		r.log.Error("scanRowToPR: select reviewers failed", "pr_id", pr.PullRequestId, "err", err)
 		return api.PullRequest{}, fmt.Errorf("select reviewers: %w", err)
 	}
-	pr.AssignedReviewers = reviewers
+	pr.AssignedReviewers = make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer.UserId)
+		assignment := api.ReviewerAssignment{
+			UserId:   reviewer.UserId,
+			TeamName: reviewer.TeamName.String,
+			Source:   api.ReviewerSource(reviewer.Source),
+		}
+		if reviewer.AssignmentSeed.Valid {
+			seed := reviewer.AssignmentSeed.Int64
+			assignment.AssignmentSeed = &seed
+		}
+		pr.Reviewers = append(pr.Reviewers, assignment)
+	}
 
 	return pr, nil
 }
@@ -103,6 +175,9 @@ func (r *PullRequestRepository) scanRowToPR(scanner interface{ Scan(dest ...any)
 func (r *PullRequestRepository) FindPRByID(prID string) (*api.PullRequest, error) {
 	row := r.db.QueryRowx(qSelectPRByID, prID)
 	pr, err := r.scanRowToPR(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrPRNotFound
+	}
 	if err != nil {
 		r.log.Error("FindPRByID failed", "pr_id", prID, "err", err)
 		return nil, fmt.Errorf("find pr by id: %w", err)
@@ -110,38 +185,163 @@ func (r *PullRequestRepository) FindPRByID(prID string) (*api.PullRequest, error
 	return &pr, nil
 }
 
-func (r *PullRequestRepository) UpdatePR(pr api.PullRequest) error {
+// UpdatePR rewrites the PR with its reviewers and, when given, stores the
+// trace of the assignment that changed them in the same transaction.
+func (r *PullRequestRepository) UpdatePR(pr api.PullRequest, trace *api.AssignmentTrace) error {
 	err := r.withTx(func(tx *sqlx.Tx) error {
-		var mergedAt any
-		if pr.MergedAt != nil {
-			mergedAt = pr.MergedAt
+		if err := updatePR(tx, pr); err != nil {
+			return err
 		}
+		return insertTrace(tx, trace)
+	})
+	if err != nil {
+		r.log.Error("UpdatePR failed", "pr_id", pr.PullRequestId, "err", err)
+		return err
+	}
+	r.log.Info("UpdatePR succeeded", "pr_id", pr.PullRequestId)
+	return nil
+}
 
-		if _, err := tx.Exec(`UPDATE pull_requests SET status = $1, merged_at = $2 WHERE pull_request_id = $3`, pr.Status, mergedAt, pr.PullRequestId); err != nil {
-			return fmt.Errorf("update pull_request: %w", err)
+// MoveReviews hands each move's review from one reviewer to another in one
+// transaction. Every moved PR is locked and re-checked first: if it is no
+// longer OPEN, no longer reviewed by the move's source or already reviewed by
+// its target, nothing is written and repository.ErrPRChanged is returned. The
+// moved review keeps its source; only the reviewer and their team change.
+// The traces of the moves are stored in the same transaction.
+func (r *PullRequestRepository) MoveReviews(teamName string, moves []api.RebalanceMove, traces []api.AssignmentTrace) error {
+	err := r.withTx(func(tx *sqlx.Tx) error {
+		for _, m := range moves {
+			reviewers, err := lockOpenPR(tx, m.PullRequestId)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(reviewers, m.FromUserId) || slices.Contains(reviewers, m.ToUserId) {
+				return repository.ErrPRChanged
+			}
+			if _, err := tx.Exec(qMoveReviewer, m.PullRequestId, m.FromUserId, m.ToUserId, teamName); err != nil {
+				return fmt.Errorf("move reviewer on %s: %w", m.PullRequestId, err)
+			}
 		}
+		for i := range traces {
+			if err := insertTrace(tx, &traces[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("MoveReviews failed", "team", teamName, "moves", len(moves), "err", err)
+		return err
+	}
+	r.log.Info("MoveReviews succeeded", "team", teamName, "moves", len(moves))
+	return nil
+}
 
-		if _, err := tx.Exec(`DELETE FROM pr_reviewers WHERE pull_request_id = $1`, pr.PullRequestId); err != nil {
-			return fmt.Errorf("delete old reviewers: %w", err)
+// AddReviewer adds one reviewer to an OPEN PR whose reviewers are still the
+// expected ones, together with the trace of the change; otherwise it writes
+// nothing and returns repository.ErrPRChanged.
+func (r *PullRequestRepository) AddReviewer(prID string, expected []string, reviewer api.ReviewerAssignment, trace *api.AssignmentTrace) error {
+	err := r.withTx(func(tx *sqlx.Tx) error {
+		if err := lockExpectedReviewers(tx, prID, expected); err != nil {
+			return err
+		}
+		teamName := sql.NullString{String: reviewer.TeamName, Valid: reviewer.TeamName != ""}
+		var seed sql.NullInt64
+		if reviewer.AssignmentSeed != nil {
+			seed = sql.NullInt64{Int64: *reviewer.AssignmentSeed, Valid: true}
+		}
+		if _, err := tx.Exec(qInsertReviewer, prID, reviewer.UserId, teamName, seed, reviewer.Source); err != nil {
+			return fmt.Errorf("insert reviewer: %w", err)
 		}
+		return insertTrace(tx, trace)
+	})
+	if err != nil {
+		r.log.Error("AddReviewer failed", "pr_id", prID, "reviewer", reviewer.UserId, "err", err)
+		return err
+	}
+	r.log.Info("AddReviewer succeeded", "pr_id", prID, "reviewer", reviewer.UserId)
+	return nil
+}
 
-		for _, reviewer := range pr.AssignedReviewers {
-			if _, err := tx.Exec(`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`, pr.PullRequestId, reviewer); err != nil {
-				return fmt.Errorf("insert reviewer: %w", err)
-			}
+// RemoveReviewer removes one reviewer from an OPEN PR whose reviewers are
+// still the expected ones, together with the trace of the change; otherwise
+// it writes nothing and returns repository.ErrPRChanged.
+func (r *PullRequestRepository) RemoveReviewer(prID string, expected []string, userID string, trace *api.AssignmentTrace) error {
+	err := r.withTx(func(tx *sqlx.Tx) error {
+		if err := lockExpectedReviewers(tx, prID, expected); err != nil {
+			return err
 		}
-		return nil
+		if _, err := tx.Exec(qDeleteReviewer, prID, userID); err != nil {
+			return fmt.Errorf("delete reviewer: %w", err)
+		}
+		return insertTrace(tx, trace)
 	})
 	if err != nil {
-		r.log.Error("UpdatePR failed", "pr_id", pr.PullRequestId, "err", err)
+		r.log.Error("RemoveReviewer failed", "pr_id", prID, "reviewer", userID, "err", err)
 		return err
 	}
-	r.log.Info("UpdatePR succeeded", "pr_id", pr.PullRequestId)
+	r.log.Info("RemoveReviewer succeeded", "pr_id", prID, "reviewer", userID)
 	return nil
 }
 
-func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequest, error) {
-	rows, err := r.db.Queryx(qSelectPRsByReviewer, userID)
+// lockExpectedReviewers locks an OPEN PR and checks that its reviewers are
+// exactly the expected ones, in any order.
+func lockExpectedReviewers(tx *sqlx.Tx, prID string, expected []string) error {
+	reviewers, err := lockOpenPR(tx, prID)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(reviewers)), slices.Sorted(slices.Values(expected))) {
+		return repository.ErrPRChanged
+	}
+	return nil
+}
+
+// lockOpenPR locks the PR's row until the end of the transaction and returns
+// its reviewers. A PR that is gone or no longer OPEN gives
+// repository.ErrPRChanged.
+func lockOpenPR(tx *sqlx.Tx, prID string) ([]string, error) {
+	var status string
+	if err := tx.Get(&status, qLockPR, prID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPRChanged
+		}
+		return nil, fmt.Errorf("lock pull_request: %w", err)
+	}
+	if status != string(api.PullRequestStatusOPEN) {
+		return nil, repository.ErrPRChanged
+	}
+
+	var reviewers []string
+	if err := tx.Select(&reviewers, qSelectReviewerIDs, prID); err != nil {
+		return nil, fmt.Errorf("select reviewers: %w", err)
+	}
+	return reviewers, nil
+}
+
+func updatePR(tx *sqlx.Tx, pr api.PullRequest) error {
+	var mergedAt any
+	if pr.MergedAt != nil {
+		mergedAt = pr.MergedAt
+	}
+
+	if _, err := tx.Exec(qUpdatePR, pr.Status, mergedAt, max(pr.ReviewRound, 1),
+		pr.Repository, pr.Url, pr.SourceBranch, pr.TargetBranch, labels(pr), pr.LinesAdded, pr.LinesRemoved, pr.FilesChanged, pr.PullRequestId); err != nil {
+		return fmt.Errorf("update pull_request: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM pr_reviewers WHERE pull_request_id = $1`, pr.PullRequestId); err != nil {
+		return fmt.Errorf("delete old reviewers: %w", err)
+	}
+
+	return insertReviewers(tx, pr)
+}
+
+// FindPRsByReviewer returns the PRs the user reviews that pass the filter,
+// newest first.
+func (r *PullRequestRepository) FindPRsByReviewer(userID string, filter api.PullRequestFilter) ([]api.PullRequest, error) {
+	q, args := reviewerPRsQuery(userID, filter)
+	rows, err := r.db.Queryx(q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query prs by reviewer: %w", err)
 	}
@@ -165,6 +365,33 @@ func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequ
 	return results, nil
 }
 
+// FindOpenPRsByTeamReviewers returns the OPEN PRs reviewed by at least one
+// member of the team, newest first.
+func (r *PullRequestRepository) FindOpenPRsByTeamReviewers(teamName string) ([]api.PullRequest, error) {
+	rows, err := r.db.Queryx(qSelectOpenTeamPRs, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("query open prs by team reviewers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("rows close error: %v\n", err)
+		}
+	}()
+
+	var results []api.PullRequest
+	for rows.Next() {
+		pr, err := r.scanRowToPR(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return results, nil
+}
+
 func (r *PullRequestRepository) GetAllPRs() ([]api.PullRequest, error) {
 	rows, err := r.db.Queryx(qSelectAllPRs)
 	if err != nil {
@@ -189,3 +416,247 @@ func (r *PullRequestRepository) GetAllPRs() ([]api.PullRequest, error) {
 	}
 	return results, nil
 }
+
+// ListPRs returns up to query.Limit PRs matching the query's filters in its
+// sort order, starting after the given cursor, if any. Ties on the sort key
+// are ordered by pull_request_id so that pages never overlap.
+func (r *PullRequestRepository) ListPRs(query api.PullRequestListQuery, after *api.PullRequestCursor) ([]api.PullRequest, error) {
+	q, args := listPRsQuery(query, after)
+	rows, err := r.db.Queryx(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pr list: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("rows close error: %v\n", err)
+		}
+	}()
+
+	results := make([]api.PullRequest, 0, query.Limit)
+	for rows.Next() {
+		pr, err := r.scanRowToPR(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return results, nil
+}
+
+// reviewerPRsQuery builds the query behind FindPRsByReviewer: every set field
+// of the filter adds a condition. Labels are matched by containment, which
+// the GIN index on labels serves.
+func reviewerPRsQuery(userID string, filter api.PullRequestFilter) (string, []any) {
+	args := []any{userID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := []string{"pr.pull_request_id IN (SELECT pull_request_id FROM pr_reviewers WHERE user_id = $1)"}
+	where = append(where, filterConditions(filter, arg)...)
+	return qSelectPRColumns + " WHERE " + strings.Join(where, " AND ") + " ORDER BY pr.created_at DESC", args
+}
+
+// filterConditions returns a condition for every set field of the filter,
+// binding the values through arg.
+func filterConditions(filter api.PullRequestFilter, arg func(any) string) []string {
+	var where []string
+	if filter.Repository != "" {
+		where = append(where, "pr.repository = "+arg(filter.Repository))
+	}
+	if filter.Label != "" {
+		where = append(where, "pr.labels @> ARRAY["+arg(filter.Label)+"]::text[]")
+	}
+	if filter.SourceBranch != "" {
+		where = append(where, "pr.source_branch = "+arg(filter.SourceBranch))
+	}
+	if filter.TargetBranch != "" {
+		where = append(where, "pr.target_branch = "+arg(filter.TargetBranch))
+	}
+	if filter.MinSize != nil {
+		where = append(where, "pr.lines_added + pr.lines_removed >= "+arg(*filter.MinSize))
+	}
+	if filter.MaxSize != nil {
+		where = append(where, "pr.lines_added + pr.lines_removed <= "+arg(*filter.MaxSize))
+	}
+	return where
+}
+
+// listPRsQuery builds the keyset query behind ListPRs.
+func listPRsQuery(query api.PullRequestListQuery, after *api.PullRequestCursor) (string, []any) {
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.Status != "" {
+		where = append(where, "pr.status = "+arg(query.Status))
+	}
+	if query.AuthorId != "" {
+		where = append(where, "pr.author_id = "+arg(query.AuthorId))
+	}
+	if query.ReviewerId != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM pr_reviewers r WHERE r.pull_request_id = pr.pull_request_id AND r.user_id = "+arg(query.ReviewerId)+")")
+	}
+	if query.TeamName != "" {
+		where = append(where, "pr.author_id IN (SELECT user_id FROM users WHERE team_name = "+arg(query.TeamName)+")")
+	}
+	if query.CreatedFrom != nil {
+		where = append(where, "pr.created_at >= "+arg(*query.CreatedFrom))
+	}
+	if query.CreatedTo != nil {
+		where = append(where, "pr.created_at <= "+arg(*query.CreatedTo))
+	}
+	if query.MergedFrom != nil {
+		where = append(where, "pr.merged_at >= "+arg(*query.MergedFrom))
+	}
+	if query.MergedTo != nil {
+		where = append(where, "pr.merged_at <= "+arg(*query.MergedTo))
+	}
+	where = append(where, filterConditions(query.Filter, arg)...)
+
+	key, keyType := "pr.created_at", "timestamp"
+	switch query.Sort {
+	case api.PullRequestSortMergedAt:
+		key = "COALESCE(pr.merged_at, '-infinity')"
+	case api.PullRequestSortSize:
+		key, keyType = "(pr.lines_added + pr.lines_removed)", "int"
+	}
+	direction, cmp := "DESC", "<"
+	if query.Order == api.SortOrderAsc {
+		direction, cmp = "ASC", ">"
+	}
+	if after != nil {
+		where = append(where, fmt.Sprintf("(%s, pr.pull_request_id) %s (%s::%s, %s)", key, cmp, arg(after.Key), keyType, arg(after.PullRequestId)))
+	}
+
+	var b strings.Builder
+	b.WriteString(qSelectPRColumns)
+	if len(where) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(where, " AND "))
+	}
+	fmt.Fprintf(&b, " ORDER BY %s %s, pr.pull_request_id %s LIMIT %s", key, direction, direction, arg(query.Limit))
+	return b.String(), args
+}
+
+func (r *PullRequestRepository) CountOpenReviewsByTeam(teamName string) (map[string]int, error) {
+	var rows []models.ReviewLoad
+	if err := r.db.Select(&rows, qCountOpenReviews, teamName); err != nil {
+		r.log.Error("CountOpenReviewsByTeam failed", "team", teamName, "err", err)
+		return nil, fmt.Errorf("count open reviews: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.UserId] = row.OpenReviews
+	}
+	return counts, nil
+}
+
+// CountPairingsByAuthor returns how many of the author's PRs created since the
+// given time each user reviews.
+func (r *PullRequestRepository) CountPairingsByAuthor(authorID string, since time.Time) (map[string]int, error) {
+	var rows []models.Pairing
+	if err := r.db.Select(&rows, qCountAuthorPairings, authorID, since); err != nil {
+		r.log.Error("CountPairingsByAuthor failed", "author", authorID, "err", err)
+		return nil, fmt.Errorf("count pairings: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.ReviewerId] = row.Reviews
+	}
+	return counts, nil
+}
+
+// FindPairingsByTeam returns, per author of the team, how many of their PRs
+// created since the given time each reviewer reviews.
+func (r *PullRequestRepository) FindPairingsByTeam(teamName string, since time.Time) (map[string]map[string]int, error) {
+	var rows []models.Pairing
+	if err := r.db.Select(&rows, qSelectTeamPairings, teamName, since); err != nil {
+		r.log.Error("FindPairingsByTeam failed", "team", teamName, "err", err)
+		return nil, fmt.Errorf("select pairings: %w", err)
+	}
+
+	matrix := make(map[string]map[string]int)
+	for _, row := range rows {
+		if matrix[row.AuthorId] == nil {
+			matrix[row.AuthorId] = make(map[string]int)
+		}
+		matrix[row.AuthorId][row.ReviewerId] = row.Reviews
+	}
+	return matrix, nil
+}
+
+// insertTrace stores an assignment trace; a nil trace is skipped.
+func insertTrace(tx *sqlx.Tx, trace *api.AssignmentTrace) error {
+	if trace == nil {
+		return nil
+	}
+	body, err := json.Marshal(trace)
+	if err != nil {
+		return fmt.Errorf("marshal trace: %w", err)
+	}
+	if _, err := tx.Exec(qInsertTrace, trace.PullRequestId, trace.Action, body); err != nil {
+		return fmt.Errorf("insert trace: %w", err)
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) FindAssignmentTraces(prID string) ([]api.AssignmentTrace, error) {
+	var bodies [][]byte
+	if err := r.db.Select(&bodies, qSelectTraces, prID); err != nil {
+		r.log.Error("FindAssignmentTraces failed", "pr_id", prID, "err", err)
+		return nil, fmt.Errorf("select traces: %w", err)
+	}
+
+	traces := make([]api.AssignmentTrace, 0, len(bodies))
+	for _, body := range bodies {
+		var trace api.AssignmentTrace
+		if err := json.Unmarshal(body, &trace); err != nil {
+			return nil, fmt.Errorf("unmarshal trace: %w", err)
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+// SaveReview stores the reviewer's verdict for the review round, replacing
+// their previous verdict in that round.
+func (r *PullRequestRepository) SaveReview(review api.Review) error {
+	if _, err := r.db.Exec(qUpsertReview, review.PullRequestId, review.Round, review.UserId, review.Verdict, review.SubmittedAt); err != nil {
+		r.log.Error("SaveReview failed", "pr_id", review.PullRequestId, "user_id", review.UserId, "err", err)
+		return fmt.Errorf("upsert review: %w", err)
+	}
+	r.log.Info("SaveReview succeeded", "pr_id", review.PullRequestId, "user_id", review.UserId, "verdict", review.Verdict)
+	return nil
+}
+
+// FindReviews returns the verdicts of every review round of the PR, oldest
+// round first.
+func (r *PullRequestRepository) FindReviews(prID string) ([]api.Review, error) {
+	var rows []models.Review
+	if err := r.db.Select(&rows, qSelectReviews, prID); err != nil {
+		r.log.Error("FindReviews failed", "pr_id", prID, "err", err)
+		return nil, fmt.Errorf("select reviews: %w", err)
+	}
+
+	reviews := make([]api.Review, 0, len(rows))
+	for _, row := range rows {
+		reviews = append(reviews, api.Review{
+			PullRequestId: row.PullRequestId,
+			Round:         row.Round,
+			UserId:        row.UserId,
+			Verdict:       api.ReviewVerdict(row.Verdict),
+			SubmittedAt:   row.SubmittedAt,
+		})
+	}
+	return reviews, nil
+}
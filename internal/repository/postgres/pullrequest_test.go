@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+func TestReviewerPRsQuery(t *testing.T) {
+	ten, hundred := 10, 100
+	const byReviewer = qSelectPRColumns + " WHERE pr.pull_request_id IN (SELECT pull_request_id FROM pr_reviewers WHERE user_id = $1)"
+
+	cases := []struct {
+		name     string
+		filter   api.PullRequestFilter
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			"no filter",
+			api.PullRequestFilter{},
+			byReviewer + " ORDER BY pr.created_at DESC",
+			[]any{"u1"},
+		},
+		{
+			"repository and label",
+			api.PullRequestFilter{Repository: "org/api", Label: "bug"},
+			byReviewer + " AND pr.repository = $2 AND pr.labels @> ARRAY[$3]::text[] ORDER BY pr.created_at DESC",
+			[]any{"u1", "org/api", "bug"},
+		},
+		{
+			"branches",
+			api.PullRequestFilter{SourceBranch: "feature", TargetBranch: "main"},
+			byReviewer + " AND pr.source_branch = $2 AND pr.target_branch = $3 ORDER BY pr.created_at DESC",
+			[]any{"u1", "feature", "main"},
+		},
+		{
+			"size bounds",
+			api.PullRequestFilter{MinSize: &ten, MaxSize: &hundred},
+			byReviewer + " AND pr.lines_added + pr.lines_removed >= $2 AND pr.lines_added + pr.lines_removed <= $3 ORDER BY pr.created_at DESC",
+			[]any{"u1", 10, 100},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := reviewerPRsQuery("u1", tc.filter)
+			if sql != tc.wantSQL {
+				t.Fatalf("want SQL\n%s\ngot\n%s", tc.wantSQL, sql)
+			}
+			if !reflect.DeepEqual(args, tc.wantArgs) {
+				t.Fatalf("want args %v got %v", tc.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestListPRsQuery(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	ten, hundred := 10, 100
+	const byCreated = " ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT $2"
+
+	cases := []struct {
+		name     string
+		query    api.PullRequestListQuery
+		after    *api.PullRequestCursor
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			"created_at desc",
+			api.PullRequestListQuery{Sort: api.PullRequestSortCreatedAt, Order: api.SortOrderDesc, Limit: 21},
+			nil,
+			qSelectPRColumns + " ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT $1",
+			[]any{21},
+		},
+		{
+			"created_at asc after cursor",
+			api.PullRequestListQuery{Sort: api.PullRequestSortCreatedAt, Order: api.SortOrderAsc, Limit: 21},
+			&api.PullRequestCursor{Key: "2025-03-01T00:00:00Z", PullRequestId: "pr7"},
+			qSelectPRColumns + " WHERE (pr.created_at, pr.pull_request_id) > ($1::timestamp, $2) ORDER BY pr.created_at ASC, pr.pull_request_id ASC LIMIT $3",
+			[]any{"2025-03-01T00:00:00Z", "pr7", 21},
+		},
+		{
+			"merged_at desc after cursor",
+			api.PullRequestListQuery{Sort: api.PullRequestSortMergedAt, Order: api.SortOrderDesc, Limit: 11},
+			&api.PullRequestCursor{Key: "-infinity", PullRequestId: "pr3"},
+			qSelectPRColumns + " WHERE (COALESCE(pr.merged_at, '-infinity'), pr.pull_request_id) < ($1::timestamp, $2) ORDER BY COALESCE(pr.merged_at, '-infinity') DESC, pr.pull_request_id DESC LIMIT $3",
+			[]any{"-infinity", "pr3", 11},
+		},
+		{
+			"size asc",
+			api.PullRequestListQuery{Sort: api.PullRequestSortSize, Order: api.SortOrderAsc, Limit: 5},
+			nil,
+			qSelectPRColumns + " ORDER BY (pr.lines_added + pr.lines_removed) ASC, pr.pull_request_id ASC LIMIT $1",
+			[]any{5},
+		},
+		{
+			"size desc after cursor",
+			api.PullRequestListQuery{Sort: api.PullRequestSortSize, Order: api.SortOrderDesc, Limit: 5},
+			&api.PullRequestCursor{Key: "120", PullRequestId: "pr2"},
+			qSelectPRColumns + " WHERE ((pr.lines_added + pr.lines_removed), pr.pull_request_id) < ($1::int, $2) ORDER BY (pr.lines_added + pr.lines_removed) DESC, pr.pull_request_id DESC LIMIT $3",
+			[]any{"120", "pr2", 5},
+		},
+		{
+			"filters",
+			api.PullRequestListQuery{
+				Status:      api.PullRequestStatusOPEN,
+				AuthorId:    "a1",
+				ReviewerId:  "u1",
+				TeamName:    "backend",
+				CreatedFrom: &from,
+				Sort:        api.PullRequestSortCreatedAt,
+				Order:       api.SortOrderDesc,
+				Limit:       21,
+			},
+			nil,
+			qSelectPRColumns + " WHERE pr.status = $1 AND pr.author_id = $2" +
+				" AND EXISTS (SELECT 1 FROM pr_reviewers r WHERE r.pull_request_id = pr.pull_request_id AND r.user_id = $3)" +
+				" AND pr.author_id IN (SELECT user_id FROM users WHERE team_name = $4) AND pr.created_at >= $5" +
+				" ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT $6",
+			[]any{api.PullRequestStatusOPEN, "a1", "u1", "backend", from, 21},
+		},
+		{
+			"repository",
+			api.PullRequestListQuery{Filter: api.PullRequestFilter{Repository: "org/api"}, Limit: 21},
+			nil,
+			qSelectPRColumns + " WHERE pr.repository = $1" + byCreated,
+			[]any{"org/api", 21},
+		},
+		{
+			"label",
+			api.PullRequestListQuery{Filter: api.PullRequestFilter{Label: "bug"}, Limit: 21},
+			nil,
+			qSelectPRColumns + " WHERE pr.labels @> ARRAY[$1]::text[]" + byCreated,
+			[]any{"bug", 21},
+		},
+		{
+			"source branch",
+			api.PullRequestListQuery{Filter: api.PullRequestFilter{SourceBranch: "feature"}, Limit: 21},
+			nil,
+			qSelectPRColumns + " WHERE pr.source_branch = $1" + byCreated,
+			[]any{"feature", 21},
+		},
+		{
+			"target branch",
+			api.PullRequestListQuery{Filter: api.PullRequestFilter{TargetBranch: "main"}, Limit: 21},
+			nil,
+			qSelectPRColumns + " WHERE pr.target_branch = $1" + byCreated,
+			[]any{"main", 21},
+		},
+		{
+			"min size",
+			api.PullRequestListQuery{Filter: api.PullRequestFilter{MinSize: &ten}, Limit: 21},
+			nil,
+			qSelectPRColumns + " WHERE pr.lines_added + pr.lines_removed >= $1" + byCreated,
+			[]any{10, 21},
+		},
+		{
+			"max size",
+			api.PullRequestListQuery{Filter: api.PullRequestFilter{MaxSize: &hundred}, Limit: 21},
+			nil,
+			qSelectPRColumns + " WHERE pr.lines_added + pr.lines_removed <= $1" + byCreated,
+			[]any{100, 21},
+		},
+		{
+			"metadata filter with status and cursor",
+			api.PullRequestListQuery{Status: api.PullRequestStatusOPEN, Filter: api.PullRequestFilter{Label: "bug"}, Limit: 21},
+			&api.PullRequestCursor{Key: "2025-03-01T00:00:00Z", PullRequestId: "pr7"},
+			qSelectPRColumns + " WHERE pr.status = $1 AND pr.labels @> ARRAY[$2]::text[]" +
+				" AND (pr.created_at, pr.pull_request_id) < ($3::timestamp, $4)" +
+				" ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT $5",
+			[]any{api.PullRequestStatusOPEN, "bug", "2025-03-01T00:00:00Z", "pr7", 21},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := listPRsQuery(tc.query, tc.after)
+			if sql != tc.wantSQL {
+				t.Fatalf("want SQL\n%s\ngot\n%s", tc.wantSQL, sql)
+			}
+			if !reflect.DeepEqual(args, tc.wantArgs) {
+				t.Fatalf("want args %v got %v", tc.wantArgs, args)
+			}
+		})
+	}
+}
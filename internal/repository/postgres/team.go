@@ -24,11 +24,19 @@ func NewTeamRepository(db *sqlx.DB, logger *slog.Logger) *TeamRepository {
 }
 
 const (
-	qInsertTeam        = `INSERT INTO teams (team_name) VALUES ($1)`
-	qUpsertUser        = `INSERT INTO users (user_id, username, team_name, is_active) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active`
+	qInsertTeam        = `INSERT INTO teams (team_name, assignment_strategy, required_reviewers, max_open_reviews, working_hours_mode, working_hours_lookahead, require_senior, pairing_mode, pairing_lookback_days, required_approvals) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	qSelectTeam        = `SELECT team_name, assignment_strategy, required_reviewers, max_open_reviews, working_hours_mode, working_hours_lookahead, require_senior, pairing_mode, pairing_lookback_days, required_approvals FROM teams WHERE team_name = $1`
+	qUpdateTeam        = `UPDATE teams SET assignment_strategy = $1, required_reviewers = $2, max_open_reviews = $3, working_hours_mode = $4, working_hours_lookahead = $5, require_senior = $6, pairing_mode = $7, pairing_lookback_days = $8, required_approvals = $9 WHERE team_name = $10`
+	qDeleteFallbacks   = `DELETE FROM team_fallbacks WHERE team_name = $1`
+	qInsertFallback    = `INSERT INTO team_fallbacks (team_name, fallback_team_name, position) VALUES ($1, $2, $3)`
+	qSelectFallbacks   = `SELECT fallback_team_name FROM team_fallbacks WHERE team_name = $1 ORDER BY position`
+	qDeleteThresholds  = `DELETE FROM team_size_thresholds WHERE team_name = $1`
+	qInsertThreshold   = `INSERT INTO team_size_thresholds (team_name, min_lines, reviewers) VALUES ($1, $2, $3)`
+	qSelectThresholds  = `SELECT min_lines, reviewers FROM team_size_thresholds WHERE team_name = $1 ORDER BY min_lines`
+	qUpsertUser        = `INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews, seniority) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')) ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active, max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews), seniority = COALESCE(EXCLUDED.seniority, users.seniority)`
 	qExistsTeam        = `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
 	qSelectTeamsByUser = `SELECT DISTINCT team_name FROM users WHERE user_id = $1`
-	qSelectTeamMembers = `SELECT u.user_id as "user_id", u.username, u.is_active FROM users u WHERE u.team_name = $1 ORDER BY u.user_id`
+	qSelectTeamMembers = `SELECT u.user_id as "user_id", u.username, u.is_active, u.max_open_reviews, COALESCE(u.seniority, '') AS seniority FROM users u WHERE u.team_name = $1 ORDER BY u.user_id`
 )
 
 func (r *TeamRepository) withTx(fn func(*sqlx.Tx) error) error {
@@ -54,16 +62,19 @@ func (r *TeamRepository) withTx(fn func(*sqlx.Tx) error) error {
 
 func (r *TeamRepository) CreateTeam(team api.Team) error {
 	err := r.withTx(func(tx *sqlx.Tx) error {
-		if _, err := tx.Exec(qInsertTeam, team.TeamName); err != nil {
+		if _, err := tx.Exec(qInsertTeam, team.TeamName, team.AssignmentStrategy, team.RequiredReviewers, team.MaxOpenReviews, team.WorkingHoursMode, team.WorkingHoursLookahead, team.RequireSenior, team.PairingMode, team.PairingLookbackDays, team.RequiredApprovals); err != nil {
 			return fmt.Errorf("db: insert team: %w", err)
 		}
 
 		for _, m := range team.Members {
-			if _, err := tx.Exec(qUpsertUser, m.UserId, m.Username, team.TeamName, m.IsActive); err != nil {
+			if _, err := tx.Exec(qUpsertUser, m.UserId, m.Username, team.TeamName, m.IsActive, m.MaxOpenReviews, m.Seniority); err != nil {
 				return fmt.Errorf("db: upsert user %s: %w", m.UserId, err)
 			}
 		}
-		return nil
+		if err := insertFallbacks(tx, team); err != nil {
+			return err
+		}
+		return insertThresholds(tx, team)
 	})
 	if err != nil {
 		r.log.Error("CreateTeam failed", "team", team.TeamName, "err", err)
@@ -83,14 +94,98 @@ func (r *TeamRepository) ExistTeamByName(name string) bool {
 	return ok
 }
 
+func (r *TeamRepository) UpdateTeamSettings(team api.Team) error {
+	err := r.withTx(func(tx *sqlx.Tx) error {
+		res, err := tx.Exec(qUpdateTeam, team.AssignmentStrategy, team.RequiredReviewers, team.MaxOpenReviews, team.WorkingHoursMode, team.WorkingHoursLookahead, team.RequireSenior, team.PairingMode, team.PairingLookbackDays, team.RequiredApprovals, team.TeamName)
+		if err != nil {
+			return fmt.Errorf("db: update team: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("db: rows affected: %w", err)
+		}
+		if affected == 0 {
+			return fmt.Errorf("db: team not found")
+		}
+
+		if _, err := tx.Exec(qDeleteFallbacks, team.TeamName); err != nil {
+			return fmt.Errorf("db: delete fallbacks: %w", err)
+		}
+		if err := insertFallbacks(tx, team); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(qDeleteThresholds, team.TeamName); err != nil {
+			return fmt.Errorf("db: delete size thresholds: %w", err)
+		}
+		return insertThresholds(tx, team)
+	})
+	if err != nil {
+		r.log.Error("UpdateTeamSettings failed", "team", team.TeamName, "err", err)
+		return err
+	}
+	r.log.Info("UpdateTeamSettings succeeded", "team", team.TeamName, "strategy", team.AssignmentStrategy, "required_reviewers", team.RequiredReviewers)
+	return nil
+}
+
+func insertFallbacks(tx *sqlx.Tx, team api.Team) error {
+	for i, fallback := range team.FallbackTeams {
+		if _, err := tx.Exec(qInsertFallback, team.TeamName, fallback, i); err != nil {
+			return fmt.Errorf("db: insert fallback %s: %w", fallback, err)
+		}
+	}
+	return nil
+}
+
+func insertThresholds(tx *sqlx.Tx, team api.Team) error {
+	for _, threshold := range team.SizeThresholds {
+		if _, err := tx.Exec(qInsertThreshold, team.TeamName, threshold.MinLines, threshold.Reviewers); err != nil {
+			return fmt.Errorf("db: insert size threshold %d: %w", threshold.MinLines, err)
+		}
+	}
+	return nil
+}
+
 func (r *TeamRepository) FindTeamByName(name string) api.Team {
-	team := api.Team{TeamName: name}
+	var t models.Team
+	if err := r.db.Get(&t, qSelectTeam, name); err != nil {
+		return api.Team{}
+	}
+
 	members, err := r.FindTeamMembersByName(name)
 	if err != nil {
 		return api.Team{}
 	}
-	team.Members = members
-	return team
+
+	var fallbacks []string
+	if err := r.db.Select(&fallbacks, qSelectFallbacks, name); err != nil {
+		return api.Team{}
+	}
+
+	var thresholds []models.SizeThreshold
+	if err := r.db.Select(&thresholds, qSelectThresholds, name); err != nil {
+		return api.Team{}
+	}
+	var sizeThresholds []api.SizeThreshold
+	for _, t := range thresholds {
+		sizeThresholds = append(sizeThresholds, api.SizeThreshold{MinLines: t.MinLines, Reviewers: t.Reviewers})
+	}
+	return api.Team{
+		TeamName:           t.TeamName,
+		FallbackTeams:      fallbacks,
+		AssignmentStrategy: api.TeamAssignmentStrategy(t.AssignmentStrategy),
+		RequiredReviewers:  t.RequiredReviewers,
+		RequiredApprovals:  t.RequiredApprovals,
+		MaxOpenReviews:     t.MaxOpenReviews,
+		RequireSenior:      t.RequireSenior,
+		SizeThresholds:     sizeThresholds,
+		Members:            members,
+
+		WorkingHoursMode:      api.TeamWorkingHoursMode(t.WorkingHoursMode),
+		WorkingHoursLookahead: t.WorkingHoursLookahead,
+
+		PairingMode:         api.TeamPairingMode(t.PairingMode),
+		PairingLookbackDays: t.PairingLookbackDays,
+	}
 }
 
 func (r *TeamRepository) FindTeamsByUser(userID string) ([]string, error) {
@@ -110,9 +205,11 @@ func (r *TeamRepository) FindTeamMembersByName(teamName string) ([]api.TeamMembe
 	members := make([]api.TeamMember, 0, len(dbMembers))
 	for _, m := range dbMembers {
 		members = append(members, api.TeamMember{
-			UserId:   m.UserId,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserId:         m.UserId,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Seniority:      api.Seniority(m.Seniority),
 		})
 	}
 
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestCreateTeam_UpsertsMembers(t *testing.T) {
+	three := 3
+	// A NULL limit on conflict must fall back to the stored override, as
+	// seniority does.
+	if !strings.Contains(qUpsertUser, "max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews)") {
+		t.Fatalf("upsert overwrites max_open_reviews: %s", qUpsertUser)
+	}
+
+	cases := []struct {
+		name      string
+		member    api.TeamMember
+		wantLimit driver.Value
+	}{
+		{"re-added without a limit keeps the override", api.TeamMember{UserId: "u1", Username: "Alice", IsActive: true}, nil},
+		{"re-added with a limit sets it", api.TeamMember{UserId: "u1", Username: "Alice", IsActive: true, MaxOpenReviews: &three}, 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock: %v", err)
+			}
+			defer db.Close()
+			repo := NewTeamRepository(sqlx.NewDb(db, "postgres"), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			team := api.Team{TeamName: "backend", RequiredReviewers: 2, Members: []api.TeamMember{tc.member}}
+			mock.ExpectBegin()
+			mock.ExpectExec(qInsertTeam).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(qUpsertUser).
+				WithArgs("u1", "Alice", "backend", true, tc.wantLimit, "").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			if err := repo.CreateTeam(team); err != nil {
+				t.Fatalf("CreateTeam failed: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package postgres
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
 	"github.com/V1merX/pr-reviewer-service/internal/repository/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type UserRepository struct {
@@ -21,17 +24,69 @@ func NewUserRepository(db *sqlx.DB, logger *slog.Logger) *UserRepository {
 	}
 }
 
+const (
+	qDeleteUserSkills     = `DELETE FROM user_skills WHERE user_id = $1`
+	qInsertUserSkill      = `INSERT INTO user_skills (user_id, skill) VALUES ($1, $2)`
+	qSelectSkillsForUsers = `SELECT user_id, skill FROM user_skills WHERE user_id = ANY($1) ORDER BY user_id, skill`
+
+	qInsertUnavailability    = `INSERT INTO user_unavailability (user_id, starts_on, ends_on, reason) VALUES ($1, $2, $3, $4) RETURNING id`
+	qSelectUnavailability    = `SELECT id, user_id, to_char(starts_on, 'YYYY-MM-DD') AS starts_on, to_char(ends_on, 'YYYY-MM-DD') AS ends_on, reason FROM user_unavailability`
+	qUpdateUnavailability    = `UPDATE user_unavailability SET starts_on = $1, ends_on = $2, reason = $3 WHERE id = $4`
+	qDeleteUnavailability    = `DELETE FROM user_unavailability WHERE id = $1`
+	qSelectUnavailableOnDate = `SELECT DISTINCT user_id FROM user_unavailability WHERE $1::date BETWEEN starts_on AND ends_on`
+
+	qUpdateWorkingHours         = `UPDATE users SET timezone = $1, work_start = $2, work_end = $3 WHERE user_id = $4`
+	qSelectWorkingHoursForUsers = `SELECT user_id, timezone, work_start, work_end FROM users WHERE user_id = ANY($1) AND timezone IS NOT NULL`
+)
+
+func (r *UserRepository) withTx(fn func(*sqlx.Tx) error) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("db: begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			fmt.Printf("tx rollback error: %v\n", err)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit: %w", err)
+	}
+	return nil
+}
+
 func (r *UserRepository) FindUserByID(userID string) (*api.User, error) {
 	var u models.User
-	query := `SELECT user_id, username, team_name, is_active FROM users WHERE user_id = $1`
+	query := `SELECT user_id, username, team_name, is_active, max_open_reviews, COALESCE(seniority, '') AS seniority FROM users WHERE user_id = $1`
 	if err := r.db.Get(&u, query, userID); err != nil {
 		return nil, fmt.Errorf("db: get user: %w", err)
 	}
 	user := api.User{
-		UserId:   u.UserId,
-		Username: u.Username,
-		TeamName: u.TeamName,
-		IsActive: u.IsActive,
+		UserId:         u.UserId,
+		Username:       u.Username,
+		TeamName:       u.TeamName,
+		IsActive:       u.IsActive,
+		MaxOpenReviews: u.MaxOpenReviews,
+		Seniority:      api.Seniority(u.Seniority),
+	}
+
+	skills, err := r.FindSkillsByUsers([]string{userID})
+	if err != nil {
+		return nil, err
+	}
+	user.Skills = skills[userID]
+
+	hours, err := r.FindWorkingHoursByUsers([]string{userID})
+	if err != nil {
+		return nil, err
+	}
+	if wh, ok := hours[userID]; ok {
+		user.WorkingHours = &wh
 	}
 	return &user, nil
 }
@@ -52,20 +107,210 @@ func (r *UserRepository) UpdateUserStatus(userID string, status bool) error {
 	return nil
 }
 
+func (r *UserRepository) UpdateUserMaxOpenReviews(userID string, limit *int) error {
+	res, err := r.db.Exec("UPDATE users SET max_open_reviews = $1 WHERE user_id = $2", limit, userID)
+	if err != nil {
+		return fmt.Errorf("db: update user max open reviews: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("db: user not found")
+	}
+	r.log.Info("UpdateUserMaxOpenReviews succeeded", "user", userID, "max_open_reviews", limit)
+	return nil
+}
+
+func (r *UserRepository) UpdateUserSeniority(userID string, seniority api.Seniority) error {
+	res, err := r.db.Exec("UPDATE users SET seniority = NULLIF($1, '') WHERE user_id = $2", seniority, userID)
+	if err != nil {
+		return fmt.Errorf("db: update user seniority: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("db: user not found")
+	}
+	r.log.Info("UpdateUserSeniority succeeded", "user", userID, "seniority", seniority)
+	return nil
+}
+
 func (r *UserRepository) GetAllUsers() ([]api.User, error) {
 	var dbUsers []models.User
-	query := `SELECT user_id, username, team_name, is_active FROM users`
+	query := `SELECT user_id, username, team_name, is_active, max_open_reviews, COALESCE(seniority, '') AS seniority FROM users`
 	if err := r.db.Select(&dbUsers, query); err != nil {
 		return nil, fmt.Errorf("db: select users: %w", err)
 	}
 	users := make([]api.User, 0, len(dbUsers))
 	for _, u := range dbUsers {
 		users = append(users, api.User{
-			UserId:   u.UserId,
-			Username: u.Username,
-			TeamName: u.TeamName,
-			IsActive: u.IsActive,
+			UserId:         u.UserId,
+			Username:       u.Username,
+			TeamName:       u.TeamName,
+			IsActive:       u.IsActive,
+			MaxOpenReviews: u.MaxOpenReviews,
+			Seniority:      api.Seniority(u.Seniority),
 		})
 	}
 	return users, nil
 }
+
+func (r *UserRepository) SetUserSkills(userID string, skills []string) error {
+	err := r.withTx(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(qDeleteUserSkills, userID); err != nil {
+			return fmt.Errorf("db: delete user skills: %w", err)
+		}
+		for _, skill := range skills {
+			if _, err := tx.Exec(qInsertUserSkill, userID, skill); err != nil {
+				return fmt.Errorf("db: insert user skill: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("SetUserSkills failed", "user", userID, "err", err)
+		return err
+	}
+	r.log.Info("SetUserSkills succeeded", "user", userID, "skills", skills)
+	return nil
+}
+
+func (r *UserRepository) FindSkillsByUsers(userIDs []string) (map[string][]string, error) {
+	var rows []models.UserSkill
+	if err := r.db.Select(&rows, qSelectSkillsForUsers, pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("db: select user skills: %w", err)
+	}
+
+	skills := make(map[string][]string, len(userIDs))
+	for _, row := range rows {
+		skills[row.UserId] = append(skills[row.UserId], row.Skill)
+	}
+	return skills, nil
+}
+
+func (r *UserRepository) CreateUnavailability(u api.Unavailability) (int64, error) {
+	var id int64
+	if err := r.db.QueryRow(qInsertUnavailability, u.UserId, u.StartDate, u.EndDate, u.Reason).Scan(&id); err != nil {
+		return 0, fmt.Errorf("db: insert unavailability: %w", err)
+	}
+	r.log.Info("CreateUnavailability succeeded", "user", u.UserId, "id", id, "start", u.StartDate, "end", u.EndDate)
+	return id, nil
+}
+
+func (r *UserRepository) FindUnavailabilityByID(id int64) (*api.Unavailability, error) {
+	var u models.Unavailability
+	if err := r.db.Get(&u, qSelectUnavailability+` WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("db: get unavailability: %w", err)
+	}
+	result := toAPIUnavailability(u)
+	return &result, nil
+}
+
+func (r *UserRepository) FindUnavailabilityByUser(userID string) ([]api.Unavailability, error) {
+	var rows []models.Unavailability
+	if err := r.db.Select(&rows, qSelectUnavailability+` WHERE user_id = $1 ORDER BY starts_on, id`, userID); err != nil {
+		return nil, fmt.Errorf("db: select unavailability: %w", err)
+	}
+
+	ranges := make([]api.Unavailability, 0, len(rows))
+	for _, u := range rows {
+		ranges = append(ranges, toAPIUnavailability(u))
+	}
+	return ranges, nil
+}
+
+func (r *UserRepository) UpdateUnavailability(u api.Unavailability) error {
+	res, err := r.db.Exec(qUpdateUnavailability, u.StartDate, u.EndDate, u.Reason, u.Id)
+	if err != nil {
+		return fmt.Errorf("db: update unavailability: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("db: unavailability not found")
+	}
+	r.log.Info("UpdateUnavailability succeeded", "id", u.Id, "start", u.StartDate, "end", u.EndDate)
+	return nil
+}
+
+func (r *UserRepository) DeleteUnavailability(id int64) error {
+	res, err := r.db.Exec(qDeleteUnavailability, id)
+	if err != nil {
+		return fmt.Errorf("db: delete unavailability: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("db: unavailability not found")
+	}
+	r.log.Info("DeleteUnavailability succeeded", "id", id)
+	return nil
+}
+
+func (r *UserRepository) FindUnavailableUserIDs(day string) (map[string]bool, error) {
+	var userIDs []string
+	if err := r.db.Select(&userIDs, qSelectUnavailableOnDate, day); err != nil {
+		return nil, fmt.Errorf("db: select unavailable users: %w", err)
+	}
+
+	unavailable := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		unavailable[id] = true
+	}
+	return unavailable, nil
+}
+
+func toAPIUnavailability(u models.Unavailability) api.Unavailability {
+	return api.Unavailability{
+		Id:        u.Id,
+		UserId:    u.UserId,
+		StartDate: u.StartDate,
+		EndDate:   u.EndDate,
+		Reason:    u.Reason,
+	}
+}
+
+func (r *UserRepository) UpdateUserWorkingHours(userID string, hours *api.WorkingHours) error {
+	var timezone, start, end *string
+	if hours != nil {
+		timezone, start, end = &hours.Timezone, &hours.Start, &hours.End
+	}
+
+	res, err := r.db.Exec(qUpdateWorkingHours, timezone, start, end, userID)
+	if err != nil {
+		return fmt.Errorf("db: update working hours: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("db: user not found")
+	}
+	r.log.Info("UpdateUserWorkingHours succeeded", "user", userID, "working_hours", hours)
+	return nil
+}
+
+func (r *UserRepository) FindWorkingHoursByUsers(userIDs []string) (map[string]api.WorkingHours, error) {
+	var rows []models.UserWorkingHours
+	if err := r.db.Select(&rows, qSelectWorkingHoursForUsers, pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("db: select working hours: %w", err)
+	}
+
+	hours := make(map[string]api.WorkingHours, len(rows))
+	for _, row := range rows {
+		if row.Timezone == nil || row.WorkStart == nil || row.WorkEnd == nil {
+			continue
+		}
+		hours[row.UserId] = api.WorkingHours{Timezone: *row.Timezone, Start: *row.WorkStart, End: *row.WorkEnd}
+	}
+	return hours, nil
+}
@@ -1,26 +1,68 @@
 package repository
 
-import "github.com/V1merX/pr-reviewer-service/internal/api"
+import (
+	"errors"
+	"time"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+var (
+	// ErrPRChanged is returned by writes that re-check a PR under lock when
+	// the PR is no longer in the state the caller read it in.
+	ErrPRChanged = errors.New("PR changed concurrently")
+	// ErrPRNotFound is returned when no PR has the requested ID.
+	ErrPRNotFound = errors.New("PR not found")
+)
 
 type UserRepository interface {
 	FindUserByID(userID string) (*api.User, error)
 	UpdateUserStatus(userID string, status bool) error
+	UpdateUserMaxOpenReviews(userID string, limit *int) error
+	UpdateUserSeniority(userID string, seniority api.Seniority) error
 	GetAllUsers() ([]api.User, error)
+	SetUserSkills(userID string, skills []string) error
+	FindSkillsByUsers(userIDs []string) (map[string][]string, error)
+	CreateUnavailability(u api.Unavailability) (int64, error)
+	FindUnavailabilityByID(id int64) (*api.Unavailability, error)
+	FindUnavailabilityByUser(userID string) ([]api.Unavailability, error)
+	UpdateUnavailability(u api.Unavailability) error
+	DeleteUnavailability(id int64) error
+	FindUnavailableUserIDs(day string) (map[string]bool, error)
+	UpdateUserWorkingHours(userID string, hours *api.WorkingHours) error
+	FindWorkingHoursByUsers(userIDs []string) (map[string]api.WorkingHours, error)
 }
 
 type PullRequestRepository interface {
-	CreatePR(pr api.PullRequest) error
+	CreatePR(pr api.PullRequest, trace *api.AssignmentTrace) error
 	FindPRByID(prID string) (*api.PullRequest, error)
-	UpdatePR(pr api.PullRequest) error
-	FindPRsByReviewer(userID string) ([]api.PullRequest, error)
+	UpdatePR(pr api.PullRequest, trace *api.AssignmentTrace) error
+	FindPRsByReviewer(userID string, filter api.PullRequestFilter) ([]api.PullRequest, error)
+	FindOpenPRsByTeamReviewers(teamName string) ([]api.PullRequest, error)
+	MoveReviews(teamName string, moves []api.RebalanceMove, traces []api.AssignmentTrace) error
+	AddReviewer(prID string, expected []string, reviewer api.ReviewerAssignment, trace *api.AssignmentTrace) error
+	RemoveReviewer(prID string, expected []string, userID string, trace *api.AssignmentTrace) error
 	GetAllPRs() ([]api.PullRequest, error)
+	ListPRs(query api.PullRequestListQuery, after *api.PullRequestCursor) ([]api.PullRequest, error)
+	CountOpenReviewsByTeam(teamName string) (map[string]int, error)
+	CountPairingsByAuthor(authorID string, since time.Time) (map[string]int, error)
+	FindPairingsByTeam(teamName string, since time.Time) (map[string]map[string]int, error)
+	FindAssignmentTraces(prID string) ([]api.AssignmentTrace, error)
+	SaveReview(review api.Review) error
+	FindReviews(prID string) ([]api.Review, error)
 }
 
 type TeamRepository interface {
 	CreateTeam(team api.Team) error
 	UpdateTeam(team api.Team) error
+	UpdateTeamSettings(team api.Team) error
 	ExistTeamByName(name string) bool
 	FindTeamByName(name string) api.Team
 	FindTeamsByUser(userID string) ([]string, error)
 	FindTeamMembersByName(teamName string) ([]api.TeamMember, error)
 }
+
+type CodeOwnersRepository interface {
+	ReplaceRules(repository string, rules []api.CodeOwnersRule) error
+	FindRules(repository string) ([]api.CodeOwnersRule, error)
+}
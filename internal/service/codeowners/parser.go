@@ -0,0 +1,131 @@
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+// Parse reads CODEOWNERS content. Every non-empty, non-comment line is a
+// pattern followed by zero or more @owners; a pattern without owners unsets
+// ownership for the matching paths.
+func Parse(content string) ([]api.CodeOwnersRule, error) {
+	var rules []api.CodeOwnersRule
+
+	for i, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern, owners := fields[0], fields[1:]
+		if _, err := compilePattern(pattern); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %q", ErrInvalidPattern, i+1, pattern)
+		}
+		for _, owner := range owners {
+			if !strings.HasPrefix(owner, "@") || len(owner) == 1 {
+				return nil, fmt.Errorf("%w: line %d: %q", ErrInvalidOwner, i+1, owner)
+			}
+		}
+
+		rules = append(rules, api.CodeOwnersRule{Pattern: pattern, Owners: owners})
+	}
+
+	return rules, nil
+}
+
+// Owners returns the owners of the given paths. As in GitHub, the last rule
+// matching a path wins. Owner names are returned without the leading "@" and
+// without an organisation prefix, deduplicated in first-seen order.
+func Owners(rules []api.CodeOwnersRule, paths []string) []string {
+	compiled := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		re, err := compilePattern(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		compiled[i] = re
+	}
+
+	seen := make(map[string]bool)
+	var owners []string
+	for _, path := range paths {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+
+		for i := len(rules) - 1; i >= 0; i-- {
+			if compiled[i] == nil || !compiled[i].MatchString(path) {
+				continue
+			}
+			for _, owner := range rules[i].Owners {
+				name := ownerName(owner)
+				if !seen[name] {
+					seen[name] = true
+					owners = append(owners, name)
+				}
+			}
+			break
+		}
+	}
+	return owners
+}
+
+func ownerName(owner string) string {
+	name := strings.TrimPrefix(owner, "@")
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
+// compilePattern converts a CODEOWNERS glob into a regular expression.
+// Patterns with a leading or inner slash are anchored at the repository root,
+// others match at any depth. "*" and "?" stay within one path segment, "**"
+// spans segments, and a match on a directory covers everything below it.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var b strings.Builder
+	if anchored || trimmed == "" {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '*':
+			switch {
+			case strings.HasPrefix(trimmed[i:], "**/"):
+				b.WriteString("(?:.*/)?")
+				i += 2
+			case strings.HasPrefix(trimmed[i:], "**"):
+				b.WriteString(".*")
+				i++
+			default:
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case trimmed == "":
+		b.WriteString(".*$")
+	case dirOnly:
+		b.WriteString("/.*$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
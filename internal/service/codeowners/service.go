@@ -0,0 +1,56 @@
+package codeowners
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/repository"
+)
+
+var (
+	ErrCodeOwnersNotFound = errors.New("codeowners not found")
+	ErrRepositoryRequired = errors.New("repository is required")
+	ErrInvalidPattern     = errors.New("invalid codeowners pattern")
+	ErrInvalidOwner       = errors.New("invalid codeowners owner")
+)
+
+type Service struct {
+	log  *slog.Logger
+	repo repository.CodeOwnersRepository
+}
+
+func NewService(log *slog.Logger, codeOwnersRepository repository.CodeOwnersRepository) *Service {
+	return &Service{log: log, repo: codeOwnersRepository}
+}
+
+func (s *Service) SetCodeOwners(repo, content string) (*api.CodeOwners, error) {
+	if repo == "" {
+		return nil, ErrRepositoryRequired
+	}
+
+	rules, err := Parse(content)
+	if err != nil {
+		s.log.Error("SetCodeOwners: parse failed", "repository", repo, "err", err)
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceRules(repo, rules); err != nil {
+		s.log.Error("SetCodeOwners: failed to store rules", "repository", repo, "err", err)
+		return nil, fmt.Errorf("store codeowners: %w", err)
+	}
+	s.log.Info("SetCodeOwners: rules stored", "repository", repo, "rules", len(rules))
+	return &api.CodeOwners{Repository: repo, Rules: rules}, nil
+}
+
+func (s *Service) GetCodeOwners(repo string) (*api.CodeOwners, error) {
+	rules, err := s.repo.FindRules(repo)
+	if err != nil {
+		return nil, fmt.Errorf("find codeowners: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, ErrCodeOwnersNotFound
+	}
+	return &api.CodeOwners{Repository: repo, Rules: rules}, nil
+}
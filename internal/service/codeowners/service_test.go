@@ -0,0 +1,118 @@
+package codeowners
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+type fakeCodeOwnersRepo struct {
+	rules map[string][]api.CodeOwnersRule
+}
+
+func (f *fakeCodeOwnersRepo) ReplaceRules(repository string, rules []api.CodeOwnersRule) error {
+	f.rules[repository] = rules
+	return nil
+}
+
+func (f *fakeCodeOwnersRepo) FindRules(repository string) ([]api.CodeOwnersRule, error) {
+	return f.rules[repository], nil
+}
+
+const sampleCodeOwners = `
+# default owners
+*                   @org/backend
+*.sql               @dba @alice   # schema changes
+/docs/              @docs
+migrations/**/*.sql @dba
+/cmd/api/main.go
+`
+
+func TestParse(t *testing.T) {
+	rules, err := Parse(sampleCodeOwners)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 5 {
+		t.Fatalf("want 5 rules got %d", len(rules))
+	}
+	if rules[1].Pattern != "*.sql" || !slices.Equal(rules[1].Owners, []string{"@dba", "@alice"}) {
+		t.Fatalf("unexpected rule: %+v", rules[1])
+	}
+	if len(rules[4].Owners) != 0 {
+		t.Fatalf("expected rule without owners, got %+v", rules[4])
+	}
+
+	if _, err := Parse("*.go backend"); !errors.Is(err, ErrInvalidOwner) {
+		t.Fatalf("want ErrInvalidOwner got %v", err)
+	}
+}
+
+func TestOwners_Table(t *testing.T) {
+	rules, err := Parse(sampleCodeOwners)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{"fallback rule", []string{"internal/app/app.go"}, []string{"backend"}},
+		{"extension at any depth", []string{"internal/db/schema.sql"}, []string{"dba", "alice"}},
+		{"anchored directory", []string{"docs/README.md"}, []string{"docs"}},
+		{"directory is anchored at root", []string{"internal/docs/x.md"}, []string{"backend"}},
+		{"double star", []string{"migrations/2024/01/init.sql"}, []string{"dba"}},
+		{"rule without owners unsets ownership", []string{"cmd/api/main.go"}, nil},
+		{"owners are deduplicated", []string{"./a.sql", "/b.sql", "c.go"}, []string{"dba", "alice", "backend"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Owners(rules, tc.paths)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetCodeOwners_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name       string
+		repository string
+		content    string
+		wantErr    error
+	}{
+		{"missing repository", "", "* @backend", ErrRepositoryRequired},
+		{"invalid owner", "svc", "* backend", ErrInvalidOwner},
+		{"success", "svc", sampleCodeOwners, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeCodeOwnersRepo{rules: map[string][]api.CodeOwnersRule{}}
+			svc := NewService(logger, repo)
+			got, err := svc.SetCodeOwners(tc.repository, tc.content)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Rules) != len(repo.rules[tc.repository]) {
+				t.Fatalf("expected rules to be stored")
+			}
+		})
+	}
+}
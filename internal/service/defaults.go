@@ -0,0 +1,9 @@
+package service
+
+// DefaultRequiredReviewers is the number of reviewers a PR needs when its
+// team does not set required_reviewers.
+const DefaultRequiredReviewers = 2
+
+// DefaultPairingLookbackDays is the pairing matrix window, in days, when the
+// team does not set pairing_lookback_days.
+const DefaultPairingLookbackDays = 30
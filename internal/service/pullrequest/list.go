@@ -0,0 +1,143 @@
+package pullrequest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// ListPRs returns one page of the PRs matching the query. NextCursor, set
+// unless the page is the last one, continues the listing; it must be passed
+// back with the same sort and order.
+func (s *Service) ListPRs(query api.PullRequestListQuery) (*api.PullRequestPage, error) {
+	if err := normalizeListQuery(&query); err != nil {
+		return nil, err
+	}
+	var after *api.PullRequestCursor
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil || cursor.Sort != query.Sort || cursor.Order != query.Order {
+			return nil, ErrInvalidCursor
+		}
+		after = cursor
+	}
+
+	// One PR more than the page tells whether another page follows.
+	limit := query.Limit
+	query.Limit++
+	prs, err := s.pullRequestRepository.ListPRs(query, after)
+	if err != nil {
+		s.log.Error("ListPRs failed", "err", err)
+		return nil, err
+	}
+
+	page := &api.PullRequestPage{PullRequests: prs}
+	if len(prs) > limit {
+		last := prs[limit-1]
+		next := encodeCursor(api.PullRequestCursor{
+			Sort:          query.Sort,
+			Order:         query.Order,
+			Key:           cursorKey(last, query.Sort),
+			PullRequestId: last.PullRequestId,
+		})
+		page.PullRequests = prs[:limit]
+		page.NextCursor = &next
+	}
+	return page, nil
+}
+
+// normalizeListQuery validates the filters and fills in the default sort,
+// order and page size.
+func normalizeListQuery(query *api.PullRequestListQuery) error {
+	switch query.Status {
+	case "", api.PullRequestStatusDRAFT, api.PullRequestStatusOPEN, api.PullRequestStatusMERGED, api.PullRequestStatusCLOSED:
+	default:
+		return ErrInvalidPRStatus
+	}
+	switch query.Sort {
+	case "":
+		query.Sort = api.PullRequestSortCreatedAt
+	case api.PullRequestSortCreatedAt, api.PullRequestSortMergedAt, api.PullRequestSortSize:
+	default:
+		return ErrInvalidSort
+	}
+	switch query.Order {
+	case "":
+		query.Order = api.SortOrderDesc
+	case api.SortOrderAsc, api.SortOrderDesc:
+	default:
+		return ErrInvalidSort
+	}
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultListLimit
+	case query.Limit < 0 || query.Limit > maxListLimit:
+		return ErrInvalidLimit
+	}
+	if invertedRange(query.CreatedFrom, query.CreatedTo) || invertedRange(query.MergedFrom, query.MergedTo) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+func invertedRange(from, to *time.Time) bool {
+	return from != nil && to != nil && from.After(*to)
+}
+
+// noTime is the sort key of a PR without the sorted timestamp; such PRs sort
+// before all others.
+const noTime = "-infinity"
+
+// cursorKey returns the PR's sort key as stored in a cursor.
+func cursorKey(pr api.PullRequest, sort api.PullRequestSort) string {
+	at := pr.CreatedAt
+	switch sort {
+	case api.PullRequestSortSize:
+		return strconv.Itoa(prSize(pr))
+	case api.PullRequestSortMergedAt:
+		at = pr.MergedAt
+	}
+	if at == nil {
+		return noTime
+	}
+	return at.Format(time.RFC3339Nano)
+}
+
+func encodeCursor(cursor api.PullRequestCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor made by encodeCursor and checks that its key
+// fits its sort.
+func decodeCursor(s string) (*api.PullRequestCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cursor api.PullRequestCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.PullRequestId == "" {
+		return nil, ErrInvalidCursor
+	}
+	switch {
+	case cursor.Sort == api.PullRequestSortSize:
+		_, err = strconv.Atoi(cursor.Key)
+	case cursor.Key != noTime:
+		_, err = time.Parse(time.RFC3339Nano, cursor.Key)
+	}
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
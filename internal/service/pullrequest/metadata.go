@@ -0,0 +1,57 @@
+package pullrequest
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+// normalizeMetadata validates the repository, URL, branches, labels and size
+// of a new PR and fills in what the assignment options already say: the
+// repository used for CODEOWNERS and the number of changed files.
+func normalizeMetadata(pr *api.PullRequest, opts *api.CreatePROptions) error {
+	if pr.LinesAdded < 0 || pr.LinesRemoved < 0 || pr.FilesChanged < 0 {
+		return ErrInvalidPRSize
+	}
+	if pr.Url != "" {
+		u, err := url.ParseRequestURI(pr.Url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidPRURL
+		}
+	}
+
+	pr.Repository = strings.TrimSpace(pr.Repository)
+	switch {
+	case pr.Repository == "":
+		pr.Repository = opts.Repository
+	case opts.Repository == "":
+		opts.Repository = pr.Repository
+	}
+	if pr.FilesChanged == 0 {
+		pr.FilesChanged = len(opts.ChangedFiles)
+	}
+	pr.SourceBranch = strings.TrimSpace(pr.SourceBranch)
+	pr.TargetBranch = strings.TrimSpace(pr.TargetBranch)
+	pr.Labels = normalizeLabels(pr.Labels)
+	return nil
+}
+
+// normalizeLabels trims the labels and drops empty and repeated ones,
+// keeping the first occurrence.
+func normalizeLabels(labels []string) []string {
+	normalized := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label != "" && !slices.Contains(normalized, label) {
+			normalized = append(normalized, label)
+		}
+	}
+	return normalized
+}
+
+// prSize is the number of lines the PR adds and removes.
+func prSize(pr api.PullRequest) int {
+	return pr.LinesAdded + pr.LinesRemoved
+}
@@ -0,0 +1,84 @@
+package pullrequest
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+// SeedSource hands out the seeds reviewer assignments are drawn with. Every
+// assignment gets its own seed, which is stored with the reviewers it picked.
+// The seed alone does not replay a draw: the candidate pool, open-review
+// counts, availability at the assignment time and the round-robin position
+// feed it as well. The assignment trace records the pool and counts of every
+// step next to the seed.
+type SeedSource interface {
+	NextSeed() int64
+}
+
+// seededSource derives assignment seeds from one root seed, making the whole
+// sequence of assignments reproducible.
+type seededSource struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSeedSource returns a SeedSource whose seeds follow from root.
+func NewSeedSource(root uint64) SeedSource {
+	return &seededSource{rng: rand.New(rand.NewPCG(root, root))}
+}
+
+func (s *seededSource) NextSeed() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Int64()
+}
+
+// cryptoSource draws every assignment seed from crypto/rand.
+type cryptoSource struct{}
+
+func (cryptoSource) NextSeed() int64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		return rand.Int64()
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]) >> 1)
+}
+
+// draw is the randomness of a single assignment, along with the trace that
+// explains it.
+type draw struct {
+	seed int64
+	rand *rand.Rand
+	// trace collects the steps of the assignment; nil when none is kept.
+	trace *api.AssignmentTrace
+	// dryRun is set when the assignment is only previewed and not stored.
+	dryRun bool
+	// capacityBlocked is set when a seat stayed empty while members at their
+	// open-review limit were skipped.
+	capacityBlocked bool
+}
+
+// newDraw starts an assignment with the given seed, or with the next seed of
+// the service's source when seed is nil. A dry run without a seed takes a
+// fresh random one instead, so previews leave the source's sequence alone.
+func (s *Service) newDraw(seed *int64, dryRun bool) *draw {
+	d := &draw{dryRun: dryRun}
+	switch {
+	case seed != nil:
+		d.seed = *seed
+	case dryRun:
+		d.seed = cryptoSource{}.NextSeed()
+	default:
+		d.seed = s.seeds.NextSeed()
+	}
+	d.rand = seededRand(d.seed)
+	return d
+}
+
+func seededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+}
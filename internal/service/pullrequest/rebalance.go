@@ -0,0 +1,207 @@
+package pullrequest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/repository"
+)
+
+// RebalanceTeam evens out the open reviews of a team's active members by
+// moving reviews of OPEN PRs from the most to the least loaded members. A
+// review only moves to a member who is available, has capacity, is not the
+// PR's author and does not review it yet, and never takes the last senior
+// reviewer off a PR whose author's team requires one. Without apply the
+// planned moves are only returned; with apply they are written in one
+// transaction that re-checks every moved PR, and ErrPRChanged is returned
+// when one changed since the plan was made.
+func (s *Service) RebalanceTeam(teamName string, apply bool) (*api.TeamRebalance, error) {
+	team := s.teamRepository.FindTeamByName(teamName)
+	if team.TeamName == "" {
+		return nil, ErrTeamNotFound
+	}
+
+	pool, err := s.reviewerPool(teamName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]api.TeamMember)
+	for _, m := range pool.all {
+		if m.IsActive {
+			members[m.UserId] = m
+		}
+	}
+	loads := make(map[string]int, len(members))
+	for id := range members {
+		loads[id] = pool.openReviews[id]
+	}
+
+	open, err := s.pullRequestRepository.FindOpenPRsByTeamReviewers(teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open reviews of team %s: %w", teamName, err)
+	}
+	prs := make(map[string]*api.PullRequest, len(open))
+	reviews := make(map[string][]string, len(members))
+	for i := range open {
+		pr := &open[i]
+		prs[pr.PullRequestId] = pr
+		for _, id := range pr.AssignedReviewers {
+			if _, ok := members[id]; ok {
+				reviews[id] = append(reviews[id], pr.PullRequestId)
+			}
+		}
+	}
+
+	var traces []api.AssignmentTrace
+	plan := &api.TeamRebalance{
+		TeamName:          teamName,
+		Moves:             []api.RebalanceMove{},
+		OpenReviewsBefore: maps.Clone(loads),
+	}
+
+	for {
+		move, err := s.nextRebalanceMove(team, members, pool, loads, reviews, prs)
+		if err != nil {
+			return nil, err
+		}
+		if move == nil {
+			break
+		}
+
+		pr := prs[move.PullRequestId]
+		moveReview(pr, *move, teamName)
+		trace := s.changeTrace(pr.PullRequestId, api.AssignmentTraceActionRebalance, slices.Clone(pr.AssignedReviewers))
+		trace.ReplacedUserId = move.FromUserId
+		trace.Steps = append(trace.Steps, api.AssignmentTraceStep{
+			Stage:      api.AssignmentTraceStageReplacement,
+			TeamName:   teamName,
+			Seats:      1,
+			Candidates: []api.TraceCandidate{{UserId: move.ToUserId, OpenReviews: loads[move.ToUserId]}},
+			Picked:     []string{move.ToUserId},
+		})
+		traces = append(traces, *trace)
+		reviews[move.FromUserId] = slices.DeleteFunc(reviews[move.FromUserId], func(id string) bool { return id == move.PullRequestId })
+		reviews[move.ToUserId] = append(reviews[move.ToUserId], move.PullRequestId)
+		loads[move.FromUserId]--
+		loads[move.ToUserId]++
+		plan.Moves = append(plan.Moves, *move)
+	}
+	plan.OpenReviewsAfter = loads
+
+	if !apply || len(plan.Moves) == 0 {
+		return plan, nil
+	}
+
+	if err := s.pullRequestRepository.MoveReviews(teamName, plan.Moves, traces); err != nil {
+		s.log.Error("RebalanceTeam: update failed", "team", teamName, "err", err)
+		if errors.Is(err, repository.ErrPRChanged) {
+			return nil, ErrPRChanged
+		}
+		return nil, err
+	}
+	plan.Applied = true
+	s.log.Info("Team rebalanced", "team", teamName, "moves", len(plan.Moves), "prs", len(movedPRs(plan.Moves)))
+	return plan, nil
+}
+
+// moveReview hands the PR's review from the move's source to its target in
+// memory. The review keeps how it was assigned, so a requested review stays
+// requested.
+func moveReview(pr *api.PullRequest, move api.RebalanceMove, teamName string) {
+	source := api.ReviewerSourceAuto
+	for _, r := range pr.Reviewers {
+		if r.UserId == move.FromUserId {
+			source = r.Source
+		}
+	}
+	removeReviewer(pr, move.FromUserId)
+	addReviewers(pr, api.ReviewerAssignment{UserId: move.ToUserId, TeamName: teamName, Source: source})
+}
+
+// nextRebalanceMove finds a review to move from a more loaded member to one
+// with at least two open reviews fewer, or returns nil when the loads are as
+// even as the PRs allow. Members are tried by load and then by user_id, so
+// the plan is the same for the same state.
+func (s *Service) nextRebalanceMove(
+	team api.Team,
+	members map[string]api.TeamMember,
+	pool *teamPool,
+	loads map[string]int,
+	reviews map[string][]string,
+	prs map[string]*api.PullRequest,
+) (*api.RebalanceMove, error) {
+	byLoad := slices.Collect(maps.Keys(members))
+	sort.Slice(byLoad, func(i, j int) bool {
+		if loads[byLoad[i]] != loads[byLoad[j]] {
+			return loads[byLoad[i]] < loads[byLoad[j]]
+		}
+		return byLoad[i] < byLoad[j]
+	})
+
+	for i := len(byLoad) - 1; i >= 0; i-- {
+		from := byLoad[i]
+		for _, to := range byLoad {
+			if loads[from]-loads[to] < 2 {
+				break
+			}
+			if !s.canTakeRebalancedReview(team, members[to], pool, loads[to]) {
+				continue
+			}
+			for _, prID := range reviews[from] {
+				ok, err := s.canMoveReview(prs[prID], members[from], members[to])
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					return &api.RebalanceMove{PullRequestId: prID, FromUserId: from, ToUserId: to}, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// canTakeRebalancedReview reports whether the member can take one more review.
+func (s *Service) canTakeRebalancedReview(team api.Team, m api.TeamMember, pool *teamPool, load int) bool {
+	if reason := pool.excluded[m.UserId]; reason != "" && reason != api.CandidateExclusionAtCapacity {
+		return false
+	}
+	return hasCapacity(openReviewLimit(m.MaxOpenReviews, team), load)
+}
+
+// canMoveReview reports whether the review of PR can pass from one member to
+// another while keeping the reviewer set valid.
+func (s *Service) canMoveReview(pr *api.PullRequest, from, to api.TeamMember) (bool, error) {
+	if to.UserId == pr.AuthorId || slices.Contains(pr.AssignedReviewers, to.UserId) {
+		return false, nil
+	}
+	if !from.Seniority.IsSenior() || to.Seniority.IsSenior() {
+		return true, nil
+	}
+
+	author, err := s.findAuthor(pr.AuthorId)
+	if err != nil {
+		return false, err
+	}
+	if !s.teamRepository.FindTeamByName(author.TeamName).RequireSenior {
+		return true, nil
+	}
+	others := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(id string) bool { return id == from.UserId })
+	return s.hasSeniorReviewer(others)
+}
+
+// movedPRs lists the PRs touched by the moves, in the order first moved.
+func movedPRs(moves []api.RebalanceMove) []string {
+	var ids []string
+	for _, m := range moves {
+		if !slices.Contains(ids, m.PullRequestId) {
+			ids = append(ids, m.PullRequestId)
+		}
+	}
+	return ids
+}
@@ -1,167 +1,1412 @@
 package pullrequest
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"log/slog"
-	"math/big"
+	"slices"
 	"time"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
 	"github.com/V1merX/pr-reviewer-service/internal/repository"
+	"github.com/V1merX/pr-reviewer-service/internal/service"
+	"github.com/V1merX/pr-reviewer-service/internal/service/codeowners"
+	"github.com/V1merX/pr-reviewer-service/internal/service/skill"
 )
 
+// handoverLeadDays is how many days ahead an unavailability range may start
+// and still hand the user's open reviews over when it is added.
+const handoverLeadDays = 2
+
 type Service struct {
 	log                   *slog.Logger
 	pullRequestRepository repository.PullRequestRepository
 	teamRepository        repository.TeamRepository
 	userRepository        repository.UserRepository
+	codeOwnersRepository  repository.CodeOwnersRepository
+	strategies            map[api.TeamAssignmentStrategy]AssignmentStrategy
+	now                   func() time.Time
+	seeds                 SeedSource
+}
+
+// Option configures optional dependencies of the Service.
+type Option func(*Service)
+
+// WithClock replaces time.Now as the source of the current time.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithSeedSource replaces the crypto/rand backed source of assignment seeds.
+func WithSeedSource(seeds SeedSource) Option {
+	return func(s *Service) {
+		s.seeds = seeds
+	}
+}
+
+func NewService(
+	log *slog.Logger,
+	pullRequestRepository repository.PullRequestRepository,
+	teamRepository repository.TeamRepository,
+	userRepository repository.UserRepository,
+	codeOwnersRepository repository.CodeOwnersRepository,
+	opts ...Option,
+) *Service {
+	s := &Service{
+		log:                   log,
+		pullRequestRepository: pullRequestRepository,
+		teamRepository:        teamRepository,
+		userRepository:        userRepository,
+		codeOwnersRepository:  codeOwnersRepository,
+		strategies:            newStrategies(),
+		now:                   time.Now,
+		seeds:                 cryptoSource{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+var (
+	ErrAuthorNotFound               = errors.New("author not found")
+	ErrUserNotFound                 = errors.New("user not found")
+	ErrAuthorHasNoTeam              = errors.New("author has no team")
+	ErrPRNotFound                   = errors.New("PR not found")
+	ErrCannotReassignOnMergedPR     = errors.New("cannot reassign on merged PR")
+	ErrReviewerNotAssigned          = errors.New("reviewer is not assigned to this PR")
+	ErrNoReplacementCandidateInTeam = errors.New("no active replacement candidate in team")
+	ErrTeamNotFound                 = errors.New("team not found")
+	ErrCapacityExhausted            = errors.New("reviewer capacity exhausted")
+	ErrInvalidSkillMatch            = errors.New("unknown skill match mode")
+	ErrSkillsNotCovered             = errors.New("no reviewer covers required skills")
+	ErrNoSeniorReviewer             = errors.New("no senior reviewer available")
+	ErrInvalidLookback              = errors.New("lookback days must be positive")
+	ErrRequestedReviewerNotFound    = errors.New("requested reviewer not found")
+	ErrRequestedReviewerInactive    = errors.New("requested reviewer is inactive")
+	ErrRequestedReviewerIsAuthor    = errors.New("author cannot review own PR")
+	ErrRequestedReviewerNotAllowed  = errors.New("requested reviewer is outside allowed teams")
+	ErrReviewerAlreadyAssigned      = errors.New("reviewer is already assigned to this PR")
+	ErrCannotChangeMergedPR         = errors.New("cannot change reviewers on merged PR")
+	ErrReplacementNotEligible       = errors.New("replacement reviewer is not eligible")
+	ErrReplacementNotAllowed        = errors.New("replacement reviewer is outside allowed teams")
+	ErrInvalidTransition            = errors.New("invalid PR status transition")
+	ErrPRNotOpen                    = errors.New("PR is not open")
+	ErrInvalidVerdict               = errors.New("unknown review verdict")
+	ErrNotEnoughApprovals           = errors.New("PR does not have the required approvals")
+	ErrChangesRequested             = errors.New("a reviewer requested changes")
+	ErrInvalidPRSize                = errors.New("PR size must not be negative")
+	ErrInvalidPRURL                 = errors.New("PR url must be an http(s) URL")
+	ErrCannotUpdateMergedPR         = errors.New("cannot update merged PR")
+	ErrInvalidPRStatus              = errors.New("unknown PR status")
+	ErrInvalidSort                  = errors.New("unknown sort")
+	ErrInvalidLimit                 = errors.New("limit must be between 1 and 100")
+	ErrInvalidDateRange             = errors.New("date range starts after it ends")
+	ErrInvalidCursor                = errors.New("invalid cursor")
+	ErrPRChanged                    = errors.New("PR changed concurrently")
+	ErrLastReviewer                 = errors.New("cannot remove the last reviewer")
+)
+
+// prTransitions lists the statuses a PR may move to from each status. Merged
+// PRs are final.
+var prTransitions = map[api.PullRequestStatus][]api.PullRequestStatus{
+	api.PullRequestStatusDRAFT:  {api.PullRequestStatusOPEN, api.PullRequestStatusCLOSED},
+	api.PullRequestStatusOPEN:   {api.PullRequestStatusMERGED, api.PullRequestStatusCLOSED},
+	api.PullRequestStatusCLOSED: {api.PullRequestStatusOPEN, api.PullRequestStatusDRAFT},
+}
+
+func canTransition(from, to api.PullRequestStatus) bool {
+	return slices.Contains(prTransitions[from], to)
+}
+
+func (s *Service) GetActiveTeamMembers(authorID string) ([]api.TeamMember, error) {
+	author, err := s.findAuthor(authorID)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := s.reviewerPool(author.TeamName, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return pool.members, nil
+}
+
+func (s *Service) findAuthor(authorID string) (*api.User, error) {
+	author, err := s.userRepository.FindUserByID(authorID)
+	if err != nil {
+		return nil, ErrAuthorNotFound
+	}
+
+	if author.TeamName == "" {
+		return nil, ErrAuthorHasNoTeam
+	}
+	return author, nil
+}
+
+// authorTeam returns the team of the PR's author. Teams are loaded once and
+// kept in teams, keyed by name.
+func (s *Service) authorTeam(authorID string, teams map[string]api.Team) (api.Team, error) {
+	author, err := s.findAuthor(authorID)
+	if err != nil {
+		return api.Team{}, err
+	}
+	team, ok := teams[author.TeamName]
+	if !ok {
+		team = s.teamRepository.FindTeamByName(author.TeamName)
+		teams[author.TeamName] = team
+	}
+	return team, nil
+}
+
+// teamPool holds the members of a team that can take a new review.
+type teamPool struct {
+	members     []api.TeamMember
+	openReviews map[string]int
+	// atCapacity lists active members left out because of max_open_reviews.
+	atCapacity []api.TeamMember
+	// all and excluded keep every member of the team and why the ones not in
+	// members were left out.
+	all      []api.TeamMember
+	excluded map[string]api.CandidateExclusion
+}
+
+// reviewerPool returns the active members of the team other than the author
+// that are not on leave today and are below their open review limit.
+func (s *Service) reviewerPool(teamName, authorID string) (*teamPool, error) {
+	members, err := s.teamRepository.FindTeamMembersByName(teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	unavailable, err := s.unavailableToday()
+	if err != nil {
+		return nil, err
+	}
+
+	openReviews, err := s.pullRequestRepository.CountOpenReviewsByTeam(teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+	team := s.teamRepository.FindTeamByName(teamName)
+
+	pool := &teamPool{
+		members:     make([]api.TeamMember, 0, len(members)),
+		openReviews: openReviews,
+		all:         members,
+		excluded:    make(map[string]api.CandidateExclusion),
+	}
+	for _, m := range members {
+		switch {
+		case m.UserId == authorID:
+			pool.excluded[m.UserId] = api.CandidateExclusionAuthor
+		case !m.IsActive:
+			pool.excluded[m.UserId] = api.CandidateExclusionInactive
+		case unavailable[m.UserId]:
+			pool.excluded[m.UserId] = api.CandidateExclusionUnavailable
+		case !hasCapacity(openReviewLimit(m.MaxOpenReviews, team), openReviews[m.UserId]):
+			pool.excluded[m.UserId] = api.CandidateExclusionAtCapacity
+			pool.atCapacity = append(pool.atCapacity, m)
+		default:
+			pool.members = append(pool.members, m)
+		}
+	}
+
+	return pool, nil
+}
+
+// unavailableToday returns the users with an unavailability range covering
+// the current day.
+func (s *Service) unavailableToday() (map[string]bool, error) {
+	unavailable, err := s.userRepository.FindUnavailableUserIDs(s.now().Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load unavailable users: %w", err)
+	}
+	return unavailable, nil
+}
+
+// openReviewLimit returns the member's own limit, falling back to the team
+// default. Nil means unlimited.
+func openReviewLimit(memberLimit *int, team api.Team) *int {
+	if memberLimit != nil {
+		return memberLimit
+	}
+	return team.MaxOpenReviews
+}
+
+func hasCapacity(limit *int, openReviews int) bool {
+	return limit == nil || openReviews < *limit
+}
+
+// SelectRandomReviewers picks count random members. With requireSenior one
+// seat goes to a random senior member first, if there is one.
+func (s *Service) SelectRandomReviewers(members []api.TeamMember, count int, requireSenior bool) []string {
+	rng := s.newDraw(nil, false).rand
+	var picked []string
+	if requireSenior {
+		picked = s.pickReviewers(randomStrategy{}, AssignmentContext{Seats: 1, Rand: rng}, filterMembers(members, seniorOnly))
+	}
+	rest := replacementCandidates(members, "", picked)
+	return append(picked, s.pickReviewers(randomStrategy{}, AssignmentContext{Seats: count - len(picked), Rand: rng}, rest)...)
+}
+
+// SelectLeastLoadedReviewers picks the members with the fewest open reviews.
+// Members with equal load are ordered randomly.
+func (s *Service) SelectLeastLoadedReviewers(members []api.TeamMember, openReviews map[string]int, count int) []string {
+	return s.pickReviewers(leastLoadedStrategy{}, AssignmentContext{Seats: count, OpenReviews: openReviews, Rand: s.newDraw(nil, false).rand}, members)
+}
+
+// selectReviewers picks reviewers for the given assignment using the team's
+// assignment strategy. Teams in the "prefer" working hours mode get reviewers
+// who are at work, or start within the team's lookahead, ahead of the rest.
+// The inputs that decided the order are noted on the step, if there is one.
+func (s *Service) selectReviewers(team api.Team, assignment AssignmentContext, candidates []api.TeamMember, step *api.AssignmentTraceStep) ([]string, error) {
+	pairings, err := s.recentPairings(team, assignment.PullRequest.AuthorId, assignment.At)
+	if err != nil {
+		return nil, err
+	}
+	assignment.RecentPairings = pairings
+	for _, m := range candidates {
+		if c := traceCandidate(step, m.UserId); c != nil {
+			c.RecentPairings = pairings[m.UserId]
+		}
+	}
+
+	if team.WorkingHoursMode != api.TeamWorkingHoursModePrefer || len(candidates) == 0 {
+		return s.pickReviewers(s.strategy(team.AssignmentStrategy), assignment, candidates), nil
+	}
+
+	hours, err := s.userRepository.FindWorkingHoursByUsers(memberIDs(candidates))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load working hours: %w", err)
+	}
+	lookahead := time.Duration(team.WorkingHoursLookahead) * time.Hour
+
+	var atWorkNow, offWork []api.TeamMember
+	for _, m := range candidates {
+		isAtWork := true
+		if wh, ok := hours[m.UserId]; ok {
+			isAtWork = atWork(wh, assignment.At, lookahead)
+		}
+		if isAtWork {
+			atWorkNow = append(atWorkNow, m)
+		} else {
+			offWork = append(offWork, m)
+		}
+		if c := traceCandidate(step, m.UserId); c != nil {
+			c.AtWork = &isAtWork
+		}
+	}
+
+	picked := s.pickReviewers(s.strategy(team.AssignmentStrategy), assignment, atWorkNow)
+	if remaining := assignment.Seats - len(picked); remaining > 0 {
+		assignment.Seats = remaining
+		picked = append(picked, s.pickReviewers(s.strategy(team.AssignmentStrategy), assignment, offWork)...)
+	}
+	return picked, nil
+}
+
+func (s *Service) pickReviewers(strategy AssignmentStrategy, assignment AssignmentContext, candidates []api.TeamMember) []string {
+	count := assignment.Seats
+	if len(candidates) == 0 || count <= 0 {
+		return nil
+	}
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	assignment.Seats = count
+
+	ordered := strategy.Order(assignment, candidates)
+	if count > len(ordered) {
+		count = len(ordered)
+	}
+	picked := ordered[:count]
+	recordPicks(strategy, assignment, picked)
+	return picked
+}
+
+// recentPairings returns how many of the author's PRs each user reviewed
+// within the team's lookback window, for teams in the avoid_recent pairing
+// mode only.
+func (s *Service) recentPairings(team api.Team, authorID string, at time.Time) (map[string]int, error) {
+	if team.PairingMode != api.TeamPairingModeAvoidRecent {
+		return nil, nil
+	}
+	pairings, err := s.pullRequestRepository.CountPairingsByAuthor(authorID, at.AddDate(0, 0, -pairingLookbackDays(team)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pairings: %w", err)
+	}
+	return pairings, nil
+}
+
+func pairingLookbackDays(team api.Team) int {
+	if team.PairingLookbackDays > 0 {
+		return team.PairingLookbackDays
+	}
+	return service.DefaultPairingLookbackDays
+}
+
+func (s *Service) strategy(name api.TeamAssignmentStrategy) AssignmentStrategy {
+	if strategy, ok := s.strategies[name]; ok {
+		return strategy
+	}
+	return s.strategies[api.TeamAssignmentStrategyLeastLoaded]
+}
+
+// requiredReviewers returns how many reviewers a PR from the team needs: the
+// count of the largest size threshold the PR reaches, otherwise the team's
+// required reviewers.
+func requiredReviewers(team api.Team, pr *api.PullRequest) int {
+	required := 0
+	for _, threshold := range team.SizeThresholds {
+		if prSize(*pr) >= threshold.MinLines {
+			required = threshold.Reviewers
+		}
+	}
+	if required > 0 {
+		return required
+	}
+	if team.RequiredReviewers > 0 {
+		return team.RequiredReviewers
+	}
+	return service.DefaultRequiredReviewers
+}
+
+// CreatePR assigns reviewers to a new PR and stores it. The returned trace
+// explains the assignment and is stored alongside the PR. Drafts are stored
+// without reviewers and without a trace.
+func (s *Service) CreatePR(pr *api.PullRequest, opts api.CreatePROptions) (*api.AssignmentTrace, error) {
+	if err := normalizeMetadata(pr, &opts); err != nil {
+		return nil, err
+	}
+	pr.ReviewRound = 1
+	if opts.Draft {
+		return nil, s.createDraft(pr)
+	}
+
+	d, err := s.assignReviewers(pr, opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pullRequestRepository.CreatePR(*pr, d.trace); err != nil {
+		s.log.Error("CreatePR failed", "pr_id", pr.PullRequestId, "author", pr.AuthorId, "err", err)
+		return nil, err
+	}
+	s.log.Info("PR created", "pr_id", pr.PullRequestId, "author", pr.AuthorId, "reviewers", pr.AssignedReviewers, "seed", d.seed)
+	return d.trace, nil
+}
+
+// createDraft stores a new PR as DRAFT; reviewers come with MarkReady.
+func (s *Service) createDraft(pr *api.PullRequest) error {
+	if _, err := s.findAuthor(pr.AuthorId); err != nil {
+		return err
+	}
+	now := s.now()
+	pr.CreatedAt = &now
+	pr.Status = api.PullRequestStatusDRAFT
+	pr.AssignedReviewers = []string{}
+	pr.Reviewers = nil
+
+	if err := s.pullRequestRepository.CreatePR(*pr, nil); err != nil {
+		s.log.Error("CreatePR: draft failed", "pr_id", pr.PullRequestId, "author", pr.AuthorId, "err", err)
+		return err
+	}
+	s.log.Info("Draft PR created", "pr_id", pr.PullRequestId, "author", pr.AuthorId)
+	return nil
+}
+
+// MarkReady opens a DRAFT PR and assigns its reviewers the way CreatePR does.
+func (s *Service) MarkReady(prID string, opts api.CreatePROptions) (*api.PullRequest, *api.AssignmentTrace, error) {
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pr.Status != api.PullRequestStatusDRAFT {
+		return nil, nil, ErrInvalidTransition
+	}
+	if opts.Repository == "" {
+		opts.Repository = pr.Repository
+	}
+
+	d, err := s.assignReviewers(pr, opts, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.trace.Action = api.AssignmentTraceActionMarkReady
+
+	if err := s.pullRequestRepository.UpdatePR(*pr, d.trace); err != nil {
+		s.log.Error("MarkReady: update failed", "pr_id", prID, "err", err)
+		return nil, nil, err
+	}
+	s.log.Info("PR marked ready", "pr_id", prID, "reviewers", pr.AssignedReviewers, "seed", d.seed)
+	return pr, d.trace, nil
+}
+
+// UpdatePRSize records the new size of a PR. When an open PR grows past a
+// size threshold of its author's team, the missing reviewers are drawn the
+// way CreatePR fills its seats, with the CODEOWNERS and skill inputs of opts,
+// and the returned trace explains the picks and any shortfall; otherwise the
+// trace is nil. Reviewers are never removed when a PR shrinks.
+func (s *Service) UpdatePRSize(prID string, linesAdded, linesRemoved int, filesChanged *int, opts api.CreatePROptions) (*api.PullRequest, *api.AssignmentTrace, error) {
+	if linesAdded < 0 || linesRemoved < 0 || (filesChanged != nil && *filesChanged < 0) {
+		return nil, nil, ErrInvalidPRSize
+	}
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pr.Status == api.PullRequestStatusMERGED {
+		return nil, nil, ErrCannotUpdateMergedPR
+	}
+
+	pr.LinesAdded = linesAdded
+	pr.LinesRemoved = linesRemoved
+	if filesChanged != nil {
+		pr.FilesChanged = *filesChanged
+	}
+
+	if opts.Repository == "" {
+		opts.Repository = pr.Repository
+	}
+
+	var d *draw
+	if pr.Status == api.PullRequestStatusOPEN {
+		if d, err = s.topUpReviewers(pr, opts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var trace *api.AssignmentTrace
+	if d != nil {
+		trace = d.trace
+	}
+	if err := s.pullRequestRepository.UpdatePR(*pr, trace); err != nil {
+		s.log.Error("UpdatePRSize: update failed", "pr_id", prID, "err", err)
+		return nil, nil, err
+	}
+	s.log.Info("PR size updated", "pr_id", prID, "size", prSize(*pr), "reviewers", pr.AssignedReviewers)
+	return pr, trace, nil
+}
+
+// topUpReviewers draws the reviewers an open PR is missing for its size,
+// keeping the ones already assigned. It returns a nil draw when nothing is
+// missing. When no senior or no skill holder can be found, the PR keeps its
+// reviewers and the trace reports the seats left empty.
+func (s *Service) topUpReviewers(pr *api.PullRequest, opts api.CreatePROptions) (*draw, error) {
+	author, err := s.findAuthor(pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	team := s.teamRepository.FindTeamByName(author.TeamName)
+	required := requiredReviewers(team, pr)
+	if len(pr.AssignedReviewers) >= required {
+		return nil, nil
+	}
+
+	teams := append([]string{author.TeamName}, team.FallbackTeams...)
+	d := s.newDraw(nil, false)
+	d.trace = &api.AssignmentTrace{
+		PullRequestId:  pr.PullRequestId,
+		Action:         api.AssignmentTraceActionTopUp,
+		AssignmentSeed: d.seed,
+		At:             s.now(),
+		Steps:          []api.AssignmentTraceStep{},
+	}
+
+	assigned := assignedReviewers(pr)
+	reviewers, err := s.fillSeats(pr, d, team, teams, required, opts, assigned)
+	switch {
+	case errors.Is(err, ErrNoSeniorReviewer), errors.Is(err, ErrSkillsNotCovered):
+		s.log.Warn("topUpReviewers: keeping the assigned reviewers", "pr_id", pr.PullRequestId, "err", err)
+		reviewers = assigned
+	case err != nil:
+		return nil, err
+	}
+
+	addReviewers(pr, reviewers[len(assigned):]...)
+	d.trace.Reviewers = slices.Clone(pr.AssignedReviewers)
+	s.recordShortfall(pr, d, required)
+	return d, nil
+}
+
+// recordShortfall notes on the trace how many of the required seats no
+// eligible reviewer was left for, and whether open review limits kept
+// members off them. The PR keeps the reviewers it got.
+func (s *Service) recordShortfall(pr *api.PullRequest, d *draw, required int) {
+	if len(pr.AssignedReviewers) >= required {
+		return
+	}
+	d.trace.Shortfall = required - len(pr.AssignedReviewers)
+	d.trace.CapacityExhausted = d.capacityBlocked
+	s.log.Warn("PR is short of reviewers", "pr_id", pr.PullRequestId, "required", required, "reviewers", pr.AssignedReviewers, "capacity_blocked", d.capacityBlocked)
+}
+
+// ClosePR closes a DRAFT or OPEN PR without merging it. Its reviewers stay
+// recorded but no longer count as open reviews.
+func (s *Service) ClosePR(prID string) (*api.PullRequest, error) {
+	return s.transition(prID, api.PullRequestStatusCLOSED)
+}
+
+// ReopenPR reopens a CLOSED PR. A PR that has reviewers goes back to OPEN;
+// one without goes back to DRAFT, so that MarkReady assigns them. Reviewers
+// who could not be assigned anymore, being inactive, on leave or at their
+// open-review limit, are dropped, and the seats the PR is then missing are
+// drawn again; the trace of that draw is stored with the PR.
+func (s *Service) ReopenPR(prID string) (*api.PullRequest, error) {
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status != api.PullRequestStatusCLOSED {
+		return nil, ErrInvalidTransition
+	}
+	if len(pr.AssignedReviewers) == 0 {
+		return s.setStatus(pr, api.PullRequestStatusDRAFT, nil)
+	}
+
+	if err := s.dropIneligibleReviewers(pr); err != nil {
+		return nil, err
+	}
+	d, err := s.topUpReviewers(pr, api.CreatePROptions{})
+	if err != nil {
+		return nil, err
+	}
+	var trace *api.AssignmentTrace
+	if d != nil {
+		trace = d.trace
+	}
+	return s.setStatus(pr, api.PullRequestStatusOPEN, trace)
+}
+
+// dropIneligibleReviewers takes the reviewers off the PR who are no longer
+// in their team's reviewer pool for it.
+func (s *Service) dropIneligibleReviewers(pr *api.PullRequest) error {
+	pools := make(map[string]*teamPool)
+	for _, id := range slices.Clone(pr.AssignedReviewers) {
+		user, err := s.userRepository.FindUserByID(id)
+		if err != nil {
+			removeReviewer(pr, id)
+			continue
+		}
+		pool, ok := pools[user.TeamName]
+		if !ok {
+			if pool, err = s.reviewerPool(user.TeamName, pr.AuthorId); err != nil {
+				return err
+			}
+			pools[user.TeamName] = pool
+		}
+		if !slices.ContainsFunc(pool.members, func(m api.TeamMember) bool { return m.UserId == id }) {
+			s.log.Info("ReopenPR: reviewer dropped", "pr_id", pr.PullRequestId, "reviewer", id, "reason", pool.excluded[id])
+			removeReviewer(pr, id)
+		}
+	}
+	return nil
+}
+
+// transition moves the PR to the given status if prTransitions allow it.
+func (s *Service) transition(prID string, to api.PullRequestStatus) (*api.PullRequest, error) {
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	return s.setStatus(pr, to, nil)
+}
+
+// setStatus stores the loaded PR with the given status, together with the
+// trace of an assignment that came with the change, if any.
+func (s *Service) setStatus(pr *api.PullRequest, to api.PullRequestStatus, trace *api.AssignmentTrace) (*api.PullRequest, error) {
+	if !canTransition(pr.Status, to) {
+		return nil, ErrInvalidTransition
+	}
+
+	from := pr.Status
+	pr.Status = to
+	if err := s.pullRequestRepository.UpdatePR(*pr, trace); err != nil {
+		s.log.Error("PR status update failed", "pr_id", pr.PullRequestId, "from", from, "to", to, "err", err)
+		return nil, err
+	}
+	s.log.Info("PR status changed", "pr_id", pr.PullRequestId, "from", from, "to", to)
+	return pr, nil
+}
+
+// PreviewPR runs the assignment of CreatePR without storing the PR, its
+// trace or the round-robin position. CreatePR takes no seed, so a preview
+// cannot be replayed through it; the returned seed lets another preview
+// repeat the draw while the team stays unchanged, and together with the
+// trace explains the draw offline.
+func (s *Service) PreviewPR(pr *api.PullRequest, opts api.CreatePROptions) (*api.AssignmentPreview, error) {
+	if err := normalizeMetadata(pr, &opts); err != nil {
+		return nil, err
+	}
+	d, err := s.assignReviewers(pr, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible, err := s.GetActiveTeamMembers(pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AssignmentPreview{
+		AssignmentSeed:    d.seed,
+		Eligible:          eligible,
+		Reviewers:         pr.Reviewers,
+		Shortfall:         d.trace.Shortfall,
+		CapacityExhausted: d.trace.CapacityExhausted,
+		Trace:             d.trace,
+	}, nil
+}
+
+// assignReviewers picks the reviewers of a new PR and fills them in on pr.
+// Nothing is written; dryRun only keeps strategies from remembering picks.
+func (s *Service) assignReviewers(pr *api.PullRequest, opts api.CreatePROptions, dryRun bool) (*draw, error) {
+	author, err := s.findAuthor(pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if pr.CreatedAt == nil {
+		pr.CreatedAt = &now
+	}
+	d := s.newDraw(opts.Seed, dryRun)
+	d.trace = &api.AssignmentTrace{
+		PullRequestId:  pr.PullRequestId,
+		Action:         api.AssignmentTraceActionCreate,
+		AssignmentSeed: d.seed,
+		At:             now,
+		DryRun:         dryRun,
+		Steps:          []api.AssignmentTraceStep{},
+	}
+
+	team := s.teamRepository.FindTeamByName(author.TeamName)
+	teams := append([]string{author.TeamName}, team.FallbackTeams...)
+	required := requiredReviewers(team, pr)
+
+	reviewers, err := s.requestedReviewers(pr, d, teams, opts.RequestedReviewers)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err = s.fillSeats(pr, d, team, teams, required, opts, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.AssignedReviewers = nil
+	pr.Reviewers = nil
+	addReviewers(pr, reviewers...)
+	pr.Status = api.PullRequestStatusOPEN
+	d.trace.Reviewers = slices.Clone(pr.AssignedReviewers)
+	s.recordShortfall(pr, d, required)
+	return d, nil
+}
+
+// fillSeats runs the automatic stages after the chosen reviewers: CODEOWNERS,
+// required skills, the senior rule and the fill from the teams, in order, up
+// to required seats. It returns the chosen reviewers with the new picks.
+func (s *Service) fillSeats(
+	pr *api.PullRequest,
+	d *draw,
+	team api.Team,
+	teams []string,
+	required int,
+	opts api.CreatePROptions,
+	chosen []api.ReviewerAssignment,
+) ([]api.ReviewerAssignment, error) {
+	reviewers, err := s.codeOwnerReviewers(pr, d, opts, chosen)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.RequiredSkills) > 0 {
+		picked, err := s.skillReviewers(pr, d, teams, opts, required-len(reviewers), reviewers)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, picked...)
+	}
+
+	if team.RequireSenior {
+		picked, err := s.seniorReviewer(pr, d, teams, reviewers)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, picked...)
+	}
+
+	if len(reviewers) < required {
+		picked, err := s.drawReviewers(pr, d, api.AssignmentTraceStageFill, teams, required-len(reviewers), reviewerIDs(reviewers), nil)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, picked...)
+	}
+	return reviewers, nil
+}
+
+// requestedReviewers checks the reviewers the author asked for: each must be
+// an active member of the author's team or one of its fallback teams. They
+// skip the capacity and unavailability checks of automatic picks.
+func (s *Service) requestedReviewers(pr *api.PullRequest, d *draw, teamNames []string, userIDs []string) ([]api.ReviewerAssignment, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var reviewers []api.ReviewerAssignment
+	step := d.newStep(api.AssignmentTraceStageRequested, "", "", 0)
+	for _, id := range userIDs {
+		if slices.Contains(reviewerIDs(reviewers), id) {
+			continue
+		}
+		user, err := s.requestedReviewer(pr, id)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(teamNames, user.TeamName) {
+			return nil, ErrRequestedReviewerNotAllowed
+		}
+		reviewers = append(reviewers, api.ReviewerAssignment{UserId: user.UserId, TeamName: user.TeamName, Source: api.ReviewerSourceRequested})
+		if step != nil {
+			step.Candidates = append(step.Candidates, api.TraceCandidate{UserId: user.UserId})
+			step.Picked = append(step.Picked, user.UserId)
+		}
+	}
+	if step != nil {
+		step.Seats = len(step.Picked)
+		d.record(step)
+	}
+	return reviewers, nil
+}
+
+// requestedReviewer loads a user asked for by name and checks they can review
+// the PR: they must exist, be active and not be its author.
+func (s *Service) requestedReviewer(pr *api.PullRequest, userID string) (*api.User, error) {
+	user, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		return nil, ErrRequestedReviewerNotFound
+	}
+	switch {
+	case user.UserId == pr.AuthorId:
+		return nil, ErrRequestedReviewerIsAuthor
+	case !user.IsActive:
+		return nil, ErrRequestedReviewerInactive
+	}
+	return user, nil
+}
+
+// codeOwnerReviewers routes the PR to the owners of its changed files: every
+// owning team supplies one reviewer and owning users are added directly. The
+// owners are added to the already chosen reviewers.
+func (s *Service) codeOwnerReviewers(pr *api.PullRequest, d *draw, opts api.CreatePROptions, chosen []api.ReviewerAssignment) ([]api.ReviewerAssignment, error) {
+	if opts.Repository == "" || len(opts.ChangedFiles) == 0 {
+		return chosen, nil
+	}
+
+	rules, err := s.codeOwnersRepository.FindRules(opts.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load codeowners: %w", err)
+	}
+
+	reviewers := chosen
+	step := d.newStep(api.AssignmentTraceStageCodeowners, "", "", 0)
+	exclude := func(userID string, reason api.CandidateExclusion) {
+		if step != nil {
+			step.Candidates = append(step.Candidates, api.TraceCandidate{UserId: userID, Excluded: reason})
+		}
+	}
+	for _, owner := range codeowners.Owners(rules, opts.ChangedFiles) {
+		if s.teamRepository.ExistTeamByName(owner) {
+			picked, err := s.drawReviewers(pr, d, api.AssignmentTraceStageCodeowners, []string{owner}, 1, reviewerIDs(reviewers), nil)
+			if err != nil {
+				return nil, err
+			}
+			if len(picked) == 0 {
+				s.log.Warn("CreatePR: owning team has no available reviewer", "pr_id", pr.PullRequestId, "team", owner)
+			}
+			reviewers = append(reviewers, picked...)
+			continue
+		}
+
+		user, err := s.userRepository.FindUserByID(owner)
+		if err != nil {
+			s.log.Warn("CreatePR: unknown code owner", "pr_id", pr.PullRequestId, "owner", owner)
+			exclude(owner, api.CandidateExclusionUnknownUser)
+			continue
+		}
+		switch {
+		case user.UserId == pr.AuthorId:
+			exclude(owner, api.CandidateExclusionAuthor)
+			continue
+		case !user.IsActive:
+			exclude(owner, api.CandidateExclusionInactive)
+			continue
+		case slices.Contains(reviewerIDs(reviewers), user.UserId):
+			exclude(owner, api.CandidateExclusionAlreadyAssigned)
+			continue
+		}
+		unavailable, err := s.unavailableToday()
+		if err != nil {
+			return nil, err
+		}
+		if unavailable[user.UserId] {
+			s.log.Info("CreatePR: code owner is unavailable", "pr_id", pr.PullRequestId, "owner", owner)
+			exclude(owner, api.CandidateExclusionUnavailable)
+			continue
+		}
+		openReviews, err := s.pullRequestRepository.CountOpenReviewsByTeam(user.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count open reviews: %w", err)
+		}
+		if !hasCapacity(openReviewLimit(user.MaxOpenReviews, s.teamRepository.FindTeamByName(user.TeamName)), openReviews[user.UserId]) {
+			s.log.Warn("CreatePR: code owner is at capacity", "pr_id", pr.PullRequestId, "owner", owner)
+			exclude(owner, api.CandidateExclusionAtCapacity)
+			continue
+		}
+		reviewers = append(reviewers, api.ReviewerAssignment{UserId: user.UserId, TeamName: user.TeamName, AssignmentSeed: &d.seed, Source: api.ReviewerSourceAuto})
+		if step != nil {
+			step.Candidates = append(step.Candidates, api.TraceCandidate{UserId: user.UserId, OpenReviews: openReviews[user.UserId]})
+			step.Picked = append(step.Picked, user.UserId)
+		}
+	}
+	if step != nil && len(step.Candidates) > 0 {
+		step.Seats = len(step.Picked)
+		d.record(step)
+	}
+	return reviewers, nil
 }
 
-func NewService(
-	log *slog.Logger,
-	pullRequestRepository repository.PullRequestRepository,
-	teamRepository repository.TeamRepository,
-	userRepository repository.UserRepository,
-) *Service {
-	return &Service{
-		log:                   log,
-		pullRequestRepository: pullRequestRepository,
-		teamRepository:        teamRepository,
-		userRepository:        userRepository,
+// seniorReviewer draws one senior reviewer from the teams unless one of the
+// chosen reviewers is already senior.
+func (s *Service) seniorReviewer(pr *api.PullRequest, d *draw, teamNames []string, chosen []api.ReviewerAssignment) ([]api.ReviewerAssignment, error) {
+	hasSenior, err := s.hasSeniorReviewer(reviewerIDs(chosen))
+	if err != nil || hasSenior {
+		return nil, err
+	}
+
+	picked, err := s.drawReviewers(pr, d, api.AssignmentTraceStageSenior, teamNames, 1, reviewerIDs(chosen), seniorOnly)
+	if err != nil {
+		return nil, err
+	}
+	if len(picked) == 0 {
+		s.log.Warn("CreatePR: no senior reviewer available", "pr_id", pr.PullRequestId, "teams", teamNames)
+		return nil, ErrNoSeniorReviewer
+	}
+	return picked, nil
+}
+
+// hasSeniorReviewer reports whether any of the users is senior or above.
+func (s *Service) hasSeniorReviewer(userIDs []string) (bool, error) {
+	for _, id := range userIDs {
+		u, err := s.userRepository.FindUserByID(id)
+		if err != nil {
+			return false, fmt.Errorf("failed to load reviewer: %w", err)
+		}
+		if u.Seniority.IsSenior() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// assignedAt is the moment reviewers are picked for: the creation time of a PR
+// that is being created, the current time for later reassignments.
+func (s *Service) assignedAt(pr *api.PullRequest) time.Time {
+	if pr.Status == "" && pr.CreatedAt != nil {
+		return *pr.CreatedAt
+	}
+	return s.now()
+}
+
+// skillReviewers fills up to seats reviewers so that every required skill is
+// covered by at least one reviewer, counting the reviewers already chosen.
+// Teams are walked in order and, within a team, the candidate covering the
+// most missing skills wins, ties going to the team's assignment strategy.
+// In require mode a skill left uncovered fails the assignment.
+func (s *Service) skillReviewers(pr *api.PullRequest, d *draw, teamNames []string, opts api.CreatePROptions, seats int, chosen []api.ReviewerAssignment) ([]api.ReviewerAssignment, error) {
+	mode := opts.SkillMatch
+	if mode == "" {
+		mode = api.SkillMatchModePrefer
+	}
+	if mode != api.SkillMatchModePrefer && mode != api.SkillMatchModeRequire {
+		return nil, ErrInvalidSkillMatch
+	}
+	skills, err := skill.Normalize(opts.RequiredSkills)
+	if err != nil {
+		return nil, err
+	}
+
+	chosenSkills, err := s.userRepository.FindSkillsByUsers(reviewerIDs(chosen))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reviewer skills: %w", err)
+	}
+	missing := skills
+	for _, id := range reviewerIDs(chosen) {
+		missing = uncoveredSkills(missing, chosenSkills[id])
+	}
+
+	var picked []api.ReviewerAssignment
+	for _, teamName := range teamNames {
+		if len(missing) == 0 || len(picked) >= seats {
+			break
+		}
+
+		pool, err := s.reviewerPool(teamName, pr.AuthorId)
+		if err != nil {
+			return nil, err
+		}
+		team := s.teamRepository.FindTeamByName(teamName)
+		step := d.newStep(api.AssignmentTraceStageSkills, teamName, team.AssignmentStrategy, seats-len(picked))
+		taken := append(reviewerIDs(chosen), reviewerIDs(picked)...)
+		if step != nil {
+			step.Candidates = traceCandidates(pool, taken, nil)
+			step.MissingSkills = slices.Clone(missing)
+		}
+		candidates := replacementCandidates(pool.members, pr.AuthorId, taken)
+		if len(candidates) == 0 {
+			d.record(step)
+			continue
+		}
+
+		candidateSkills, err := s.userRepository.FindSkillsByUsers(memberIDs(candidates))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load reviewer skills: %w", err)
+		}
+		pairings, err := s.recentPairings(team, pr.AuthorId, s.assignedAt(pr))
+		if err != nil {
+			return nil, err
+		}
+		for id, n := range pairings {
+			if c := traceCandidate(step, id); c != nil {
+				c.RecentPairings = n
+			}
+		}
+
+		strategy := s.strategy(team.AssignmentStrategy)
+		assignment := AssignmentContext{
+			PullRequest:    *pr,
+			TeamName:       teamName,
+			Seats:          len(candidates),
+			OpenReviews:    pool.openReviews,
+			At:             s.assignedAt(pr),
+			RecentPairings: pairings,
+			Rand:           d.rand,
+			DryRun:         d.dryRun,
+		}
+		ordered := strategy.Order(assignment, candidates)
+
+		var teamPicks []string
+		for len(missing) > 0 && len(picked) < seats {
+			best, bestCovered := "", 0
+			for _, id := range ordered {
+				if slices.Contains(reviewerIDs(picked), id) {
+					continue
+				}
+				if covered := len(missing) - len(uncoveredSkills(missing, candidateSkills[id])); covered > bestCovered {
+					best, bestCovered = id, covered
+				}
+			}
+			if best == "" {
+				break
+			}
+			picked = append(picked, api.ReviewerAssignment{UserId: best, TeamName: teamName, AssignmentSeed: &d.seed, Source: api.ReviewerSourceAuto})
+			teamPicks = append(teamPicks, best)
+			missing = uncoveredSkills(missing, candidateSkills[best])
+			if step != nil {
+				step.Picked = append(step.Picked, best)
+			}
+		}
+		recordPicks(strategy, assignment, teamPicks)
+		d.record(step)
+	}
+
+	if len(missing) > 0 {
+		if mode == api.SkillMatchModeRequire {
+			s.log.Warn("CreatePR: required skills not covered", "pr_id", pr.PullRequestId, "skills", missing)
+			return nil, ErrSkillsNotCovered
+		}
+		s.log.Info("CreatePR: preferred skills not covered", "pr_id", pr.PullRequestId, "skills", missing)
+	}
+	return picked, nil
+}
+
+// drawReviewers fills up to seats reviewers from the given teams in order,
+// each team using its own assignment strategy. The author and the users in
+// taken are never picked, and a non-nil exclude rules out further members.
+// Seats nobody can take stay empty; if members were skipped for being at
+// capacity meanwhile, d.capacityBlocked is set. Every team drawn from adds a
+// step of the given stage to the trace.
+func (s *Service) drawReviewers(
+	pr *api.PullRequest,
+	d *draw,
+	stage api.AssignmentTraceStage,
+	teamNames []string,
+	seats int,
+	taken []string,
+	exclude func(api.TeamMember) api.CandidateExclusion,
+) ([]api.ReviewerAssignment, error) {
+	var picked []api.ReviewerAssignment
+	capacityBlocked := false
+	for _, teamName := range teamNames {
+		if len(picked) >= seats {
+			break
+		}
+
+		pool, err := s.reviewerPool(teamName, pr.AuthorId)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range filterMembers(pool.atCapacity, exclude) {
+			if !slices.Contains(taken, m.UserId) {
+				capacityBlocked = true
+			}
+		}
+		team := s.teamRepository.FindTeamByName(teamName)
+		unavailable := append(slices.Clone(taken), reviewerIDs(picked)...)
+		step := d.newStep(stage, teamName, team.AssignmentStrategy, seats-len(picked))
+		if step != nil {
+			step.Candidates = traceCandidates(pool, unavailable, exclude)
+		}
+		candidates := replacementCandidates(filterMembers(pool.members, exclude), pr.AuthorId, unavailable)
+		if len(candidates) == 0 {
+			d.record(step)
+			continue
+		}
+
+		ids, err := s.selectReviewers(team, AssignmentContext{
+			PullRequest: *pr,
+			TeamName:    teamName,
+			Seats:       seats - len(picked),
+			OpenReviews: pool.openReviews,
+			At:          s.assignedAt(pr),
+			Rand:        d.rand,
+			DryRun:      d.dryRun,
+		}, candidates, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			picked = append(picked, api.ReviewerAssignment{UserId: id, TeamName: teamName, AssignmentSeed: &d.seed, Source: api.ReviewerSourceAuto})
+		}
+		if step != nil {
+			step.Picked = append(step.Picked, ids...)
+		}
+		d.record(step)
+	}
+
+	if len(picked) < seats && capacityBlocked {
+		d.capacityBlocked = true
 	}
+	return picked, nil
+}
+
+func (s *Service) FindPRByID(prID string) (*api.PullRequest, error) {
+	return s.findPR(prID)
 }
 
-func randomIndex(n int) (int, error) {
-	maxN := big.NewInt(int64(n))
-	num, err := rand.Int(rand.Reader, maxN)
+// findPR loads the PR. Only a missing PR gives ErrPRNotFound; other
+// repository errors are passed on.
+func (s *Service) findPR(prID string) (*api.PullRequest, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		return nil, ErrPRNotFound
+	}
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("failed to load PR: %w", err)
 	}
-	return int(num.Int64()), nil
+	return pr, nil
 }
 
-var (
-	ErrAuthorNotFound               = errors.New("author not found")
-	ErrAuthorHasNoTeam              = errors.New("author has no team")
-	ErrPRNotFound                   = errors.New("PR not found")
-	ErrCannotReassignOnMergedPR     = errors.New("cannot reassign on merged PR")
-	ErrReviewerNotAssigned          = errors.New("reviewer is not assigned to this PR")
-	ErrNoReplacementCandidateInTeam = errors.New("no active replacement candidate in team")
-	ErrTeamNotFound                 = errors.New("team not found")
-)
-
-func (s *Service) GetActiveTeamMembers(authorID string) ([]api.TeamMember, error) {
-	author, err := s.userRepository.FindUserByID(authorID)
+// MergePR merges an open PR; merging a merged PR is a no-op. Unless force is
+// set, the PR needs as many approvals as its author's team requires and no
+// reviewer may have changes requested.
+func (s *Service) MergePR(prID string, force bool) (*api.PullRequest, error) {
+	pr, err := s.findPR(prID)
 	if err != nil {
-		return nil, ErrAuthorNotFound
+		return nil, err
 	}
 
-	if author.TeamName == "" {
-		return nil, ErrAuthorHasNoTeam
+	if pr.Status != api.PullRequestStatusMERGED {
+		if !canTransition(pr.Status, api.PullRequestStatusMERGED) {
+			return nil, ErrInvalidTransition
+		}
+		if !force {
+			if err := s.checkApprovals(pr); err != nil {
+				return nil, err
+			}
+		}
+		pr.Status = api.PullRequestStatusMERGED
+		now := s.now()
+		pr.MergedAt = &now
+		err = s.pullRequestRepository.UpdatePR(*pr, nil)
+		if err != nil {
+			s.log.Error("MergePR: update failed", "pr_id", prID, "err", err)
+			return nil, err
+		}
+		s.log.Info("PR merged", "pr_id", prID, "merged_at", pr.MergedAt, "forced", force)
 	}
 
-	members, err := s.teamRepository.FindTeamMembersByName(author.TeamName)
+	return pr, nil
+}
+
+// checkApprovals counts the verdicts of the PR's current reviewers in the
+// current review round; verdicts of earlier rounds and of reviewers no longer
+// assigned are ignored.
+func (s *Service) checkApprovals(pr *api.PullRequest) error {
+	reviews, err := s.pullRequestRepository.FindReviews(pr.PullRequestId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+		return fmt.Errorf("failed to load reviews: %w", err)
 	}
 
-	active := make([]api.TeamMember, 0, len(members))
-	for _, m := range members {
-		if m.IsActive && m.UserId != authorID {
-			active = append(active, m)
+	approvals := 0
+	for _, review := range reviews {
+		if review.Round != reviewRound(pr) || !slices.Contains(pr.AssignedReviewers, review.UserId) {
+			continue
+		}
+		switch review.Verdict {
+		case api.ReviewVerdictCHANGESREQUESTED:
+			return ErrChangesRequested
+		case api.ReviewVerdictAPPROVED:
+			approvals++
 		}
 	}
 
-	return active, nil
+	author, err := s.findAuthor(pr.AuthorId)
+	if err != nil {
+		return err
+	}
+	if approvals < s.teamRepository.FindTeamByName(author.TeamName).RequiredApprovals {
+		return ErrNotEnoughApprovals
+	}
+	return nil
 }
 
-func (s *Service) SelectRandomReviewers(members []api.TeamMember, count int) []string {
-	if len(members) == 0 || count <= 0 {
-		return nil
+// SubmitReview records a verdict of an assigned reviewer on an open PR for
+// the current review round. A new verdict replaces the reviewer's previous
+// one in that round.
+func (s *Service) SubmitReview(prID string, userID string, verdict api.ReviewVerdict) (*api.Review, error) {
+	if !validVerdict(verdict) {
+		return nil, ErrInvalidVerdict
 	}
-	if count > 2 {
-		count = 2
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status != api.PullRequestStatusOPEN {
+		return nil, ErrPRNotOpen
 	}
-	if count > len(members) {
-		count = len(members)
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, ErrReviewerNotAssigned
 	}
 
-	idxs := make([]int, len(members))
-	for i := range idxs {
-		idxs[i] = i
+	review := api.Review{
+		PullRequestId: prID,
+		Round:         reviewRound(pr),
+		UserId:        userID,
+		Verdict:       verdict,
+		SubmittedAt:   s.now(),
 	}
+	if err := s.pullRequestRepository.SaveReview(review); err != nil {
+		s.log.Error("SubmitReview: save failed", "pr_id", prID, "user_id", userID, "err", err)
+		return nil, err
+	}
+	s.log.Info("Review submitted", "pr_id", prID, "user_id", userID, "verdict", verdict)
+	return &review, nil
+}
 
-	for i := len(idxs) - 1; i > 0; i-- {
-		jBig, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
-		if err != nil {
-			break
-		}
-		j := int(jBig.Int64())
-		idxs[i], idxs[j] = idxs[j], idxs[i]
+// RequestReReview starts the next review round of an open PR, typically after
+// the author has addressed requested changes. Verdicts of earlier rounds are
+// kept but no longer count towards merging.
+func (s *Service) RequestReReview(prID string) (*api.PullRequest, error) {
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status != api.PullRequestStatusOPEN {
+		return nil, ErrPRNotOpen
 	}
 
-	out := make([]string, 0, count)
-	for i := 0; i < count; i++ {
-		out = append(out, members[idxs[i]].UserId)
+	pr.ReviewRound = reviewRound(pr) + 1
+	if err := s.pullRequestRepository.UpdatePR(*pr, nil); err != nil {
+		s.log.Error("RequestReReview: update failed", "pr_id", prID, "err", err)
+		return nil, err
 	}
-	return out
+	s.log.Info("Re-review requested", "pr_id", prID, "round", pr.ReviewRound)
+	return pr, nil
 }
 
-func (s *Service) CreatePR(pr *api.PullRequest) error {
-	activeMembers, err := s.GetActiveTeamMembers(pr.AuthorId)
+// GetReviews returns the verdicts of the PR grouped by review round. The
+// current round also lists the reviewers who have not given a verdict yet.
+func (s *Service) GetReviews(prID string) (*api.PullRequestReviews, error) {
+	pr, err := s.findPR(prID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return s.reviewRounds(pr)
+}
 
-	reviewers := s.SelectRandomReviewers(activeMembers, 2)
-	pr.AssignedReviewers = reviewers
-	pr.Status = api.PullRequestStatusOPEN
-	now := time.Now()
-	pr.CreatedAt = &now
+// GetPR returns the PR along with its review rounds, as GetReviews does.
+func (s *Service) GetPR(prID string) (*api.PullRequest, *api.PullRequestReviews, error) {
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rounds, err := s.reviewRounds(pr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, rounds, nil
+}
 
-	if err := s.pullRequestRepository.CreatePR(*pr); err != nil {
-		s.log.Error("CreatePR failed", "pr_id", pr.PullRequestId, "author", pr.AuthorId, "err", err)
-		return err
+func (s *Service) reviewRounds(pr *api.PullRequest) (*api.PullRequestReviews, error) {
+	prID := pr.PullRequestId
+	reviews, err := s.pullRequestRepository.FindReviews(prID)
+	if err != nil {
+		s.log.Error("GetReviews: failed to load reviews", "pr_id", prID, "err", err)
+		return nil, err
 	}
-	s.log.Info("PR created", "pr_id", pr.PullRequestId, "author", pr.AuthorId, "reviewers", pr.AssignedReviewers)
-	return nil
+
+	current := reviewRound(pr)
+	rounds := make([]api.ReviewRound, current)
+	for i := range rounds {
+		rounds[i] = api.ReviewRound{Round: i + 1, Reviews: []api.Review{}}
+	}
+	for _, review := range reviews {
+		if review.Round >= 1 && review.Round <= current {
+			rounds[review.Round-1].Reviews = append(rounds[review.Round-1].Reviews, review)
+		}
+	}
+	for _, reviewer := range pr.AssignedReviewers {
+		if !slices.ContainsFunc(rounds[current-1].Reviews, func(r api.Review) bool { return r.UserId == reviewer }) {
+			rounds[current-1].Pending = append(rounds[current-1].Pending, reviewer)
+		}
+	}
+
+	return &api.PullRequestReviews{PullRequestId: prID, ReviewRound: current, Rounds: rounds}, nil
 }
 
-func (s *Service) FindPRByID(prID string) (*api.PullRequest, error) {
-	return s.pullRequestRepository.FindPRByID(prID)
+// reviewRound is the PR's current review round; PRs stored before rounds
+// existed are in their first.
+func reviewRound(pr *api.PullRequest) int {
+	return max(pr.ReviewRound, 1)
 }
 
-func (s *Service) MergePR(prID string) (*api.PullRequest, error) {
-	pr, err := s.pullRequestRepository.FindPRByID(prID)
+func validVerdict(verdict api.ReviewVerdict) bool {
+	switch verdict {
+	case api.ReviewVerdictAPPROVED, api.ReviewVerdictCHANGESREQUESTED, api.ReviewVerdictCOMMENTED:
+		return true
+	}
+	return false
+}
+
+// AddReviewer assigns the given user to an open PR as a requested reviewer.
+// The user is checked like a reviewer requested on creation, except that any
+// team is allowed.
+func (s *Service) AddReviewer(prID string, userID string) (*api.PullRequest, error) {
+	pr, err := s.findPR(prID)
 	if err != nil {
-		return nil, ErrPRNotFound
+		return nil, err
+	}
+	if pr.Status == api.PullRequestStatusMERGED {
+		return nil, ErrCannotChangeMergedPR
+	}
+	if pr.Status != api.PullRequestStatusOPEN {
+		return nil, ErrPRNotOpen
+	}
+	if slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, ErrReviewerAlreadyAssigned
 	}
 
-	if pr.Status != api.PullRequestStatusMERGED {
-		pr.Status = api.PullRequestStatusMERGED
-		now := time.Now()
-		pr.MergedAt = &now
-		err = s.pullRequestRepository.UpdatePR(*pr)
-		if err != nil {
-			s.log.Error("MergePR: update failed", "pr_id", prID, "err", err)
-			return nil, err
+	user, err := s.requestedReviewer(pr, userID)
+	if err != nil {
+		return nil, err
+	}
+	expected := slices.Clone(pr.AssignedReviewers)
+	reviewer := api.ReviewerAssignment{UserId: user.UserId, TeamName: user.TeamName, Source: api.ReviewerSourceRequested}
+	trace := s.changeTrace(prID, api.AssignmentTraceActionAddReviewer, append(slices.Clone(expected), userID))
+	trace.Steps = append(trace.Steps, api.AssignmentTraceStep{
+		Stage:      api.AssignmentTraceStageRequested,
+		TeamName:   user.TeamName,
+		Seats:      1,
+		Candidates: []api.TraceCandidate{{UserId: userID}},
+		Picked:     []string{userID},
+	})
+
+	if err := s.pullRequestRepository.AddReviewer(prID, expected, reviewer, trace); err != nil {
+		s.log.Error("AddReviewer: update failed", "pr_id", prID, "reviewer", userID, "err", err)
+		if errors.Is(err, repository.ErrPRChanged) {
+			return nil, ErrPRChanged
 		}
-		s.log.Info("PR merged", "pr_id", prID, "merged_at", pr.MergedAt)
+		return nil, err
+	}
+	addReviewers(pr, reviewer)
+	s.log.Info("Reviewer added", "pr_id", prID, "reviewer", userID)
+	return pr, nil
+}
+
+// RemoveReviewer takes a reviewer off an open PR without a replacement. The
+// last reviewer cannot be removed, nor the only senior reviewer of a PR whose
+// author's team requires one.
+func (s *Service) RemoveReviewer(prID string, userID string) (*api.PullRequest, error) {
+	pr, err := s.findPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == api.PullRequestStatusMERGED {
+		return nil, ErrCannotChangeMergedPR
+	}
+	if pr.Status != api.PullRequestStatusOPEN {
+		return nil, ErrPRNotOpen
+	}
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, ErrReviewerNotAssigned
+	}
+	if len(pr.AssignedReviewers) == 1 {
+		return nil, ErrLastReviewer
+	}
+
+	reviewer, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("reviewer not found")
+	}
+	senior, err := s.seniorReplacementFilter(pr, reviewer)
+	if err != nil {
+		return nil, err
+	}
+	if senior != nil {
+		return nil, ErrNoSeniorReviewer
 	}
+	expected := slices.Clone(pr.AssignedReviewers)
+	remaining := slices.DeleteFunc(slices.Clone(expected), func(id string) bool { return id == userID })
+	trace := s.changeTrace(prID, api.AssignmentTraceActionRemoveReviewer, remaining)
+	trace.ReplacedUserId = userID
 
+	if err := s.pullRequestRepository.RemoveReviewer(prID, expected, userID, trace); err != nil {
+		s.log.Error("RemoveReviewer: update failed", "pr_id", prID, "reviewer", userID, "err", err)
+		if errors.Is(err, repository.ErrPRChanged) {
+			return nil, ErrPRChanged
+		}
+		return nil, err
+	}
+	removeReviewer(pr, userID)
+	s.log.Info("Reviewer removed", "pr_id", prID, "reviewer", userID)
 	return pr, nil
 }
 
-func (s *Service) ReassignReviewer(prID string, oldReviewerID string) (*api.PullRequest, *string, error) {
-	pr, err := s.pullRequestRepository.FindPRByID(prID)
+// ReassignReviewer replaces a reviewer of an open PR. The returned trace
+// explains the pick and is stored alongside the PR. A dry run returns the PR
+// as it would look after the replacement and stores nothing.
+func (s *Service) ReassignReviewer(prID string, oldReviewerID string, opts api.ReassignOptions) (*api.PullRequest, *string, *api.AssignmentTrace, error) {
+	pr, err := s.findPR(prID)
 	if err != nil {
-		return nil, nil, ErrPRNotFound
+		return nil, nil, nil, err
 	}
 
 	if pr.Status == api.PullRequestStatusMERGED {
-		return nil, nil, ErrCannotReassignOnMergedPR
+		return nil, nil, nil, ErrCannotReassignOnMergedPR
+	}
+	if pr.Status != api.PullRequestStatusOPEN {
+		return nil, nil, nil, ErrPRNotOpen
 	}
 
 	found := false
@@ -172,70 +1417,135 @@ func (s *Service) ReassignReviewer(prID string, oldReviewerID string) (*api.Pull
 		}
 	}
 	if !found {
-		return nil, nil, ErrReviewerNotAssigned
+		return nil, nil, nil, ErrReviewerNotAssigned
 	}
 
 	oldReviewer, err := s.userRepository.FindUserByID(oldReviewerID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("reviewer not found")
+		return nil, nil, nil, fmt.Errorf("reviewer not found")
 	}
 
-	members, err := s.teamRepository.FindTeamMembersByName(oldReviewer.TeamName)
+	senior, err := s.seniorReplacementFilter(pr, oldReviewer)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get team members: %w", err)
+		return nil, nil, nil, err
 	}
-
-	var candidates []api.TeamMember
-	for _, member := range members {
-		if !member.IsActive {
-			continue
+	if opts.NewUserID != "" {
+		if _, err := s.userRepository.FindUserByID(opts.NewUserID); err != nil {
+			return nil, nil, nil, ErrRequestedReviewerNotFound
 		}
-		if member.UserId == oldReviewerID {
-			continue
+	}
+	exclude := replacementFilter(senior, opts)
+
+	team := s.teamRepository.FindTeamByName(oldReviewer.TeamName)
+	teams := append([]string{oldReviewer.TeamName}, team.FallbackTeams...)
+	d := s.newDraw(nil, opts.DryRun)
+	d.trace = &api.AssignmentTrace{
+		PullRequestId:  prID,
+		Action:         api.AssignmentTraceActionReassign,
+		AssignmentSeed: d.seed,
+		At:             s.now(),
+		DryRun:         opts.DryRun,
+		ReplacedUserId: oldReviewerID,
+		Steps:          []api.AssignmentTraceStep{},
+	}
+	picked, err := s.drawReviewers(pr, d, api.AssignmentTraceStageReplacement, teams, 1, pr.AssignedReviewers, exclude)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(picked) == 0 {
+		if d.capacityBlocked {
+			return nil, nil, nil, ErrCapacityExhausted
 		}
-		alreadyReviewer := false
-		for _, reviewer := range pr.AssignedReviewers {
-			if reviewer == member.UserId {
-				alreadyReviewer = true
-				break
-			}
+		if opts.NewUserID != "" {
+			return nil, nil, nil, replacementTargetError(d.trace, opts.NewUserID)
 		}
-		if !alreadyReviewer {
-			candidates = append(candidates, member)
+		if senior != nil {
+			return nil, nil, nil, ErrNoSeniorReviewer
 		}
+		return nil, nil, nil, ErrNoReplacementCandidateInTeam
 	}
+	newReviewer := picked[0].UserId
 
-	if len(candidates) == 0 {
-		return nil, nil, ErrNoReplacementCandidateInTeam
+	removeReviewer(pr, oldReviewerID)
+	addReviewers(pr, picked[0])
+	d.trace.Reviewers = slices.Clone(pr.AssignedReviewers)
+	if opts.DryRun {
+		return pr, &newReviewer, d.trace, nil
 	}
 
-	idx, err := randomIndex(len(candidates))
+	err = s.pullRequestRepository.UpdatePR(*pr, d.trace)
 	if err != nil {
-		return nil, nil, err
+		s.log.Error("ReassignReviewer: update failed", "pr_id", prID, "old_reviewer", oldReviewerID, "err", err)
+		return nil, nil, nil, err
 	}
-	newReviewer := candidates[idx].UserId
+	s.log.Info("Reviewer reassigned", "pr_id", prID, "from", oldReviewerID, "to", newReviewer, "team", picked[0].TeamName, "seed", d.seed)
+	return pr, &newReviewer, d.trace, nil
+}
 
-	newReviewers := []string{}
-	for _, reviewer := range pr.AssignedReviewers {
-		if reviewer != oldReviewerID {
-			newReviewers = append(newReviewers, reviewer)
+// replacementFilter narrows the replacement candidates to the requested
+// target, drops the excluded users and applies the senior filter, if any.
+func replacementFilter(senior func(api.TeamMember) api.CandidateExclusion, opts api.ReassignOptions) func(api.TeamMember) api.CandidateExclusion {
+	if senior == nil && opts.NewUserID == "" && len(opts.ExcludeUserIDs) == 0 {
+		return nil
+	}
+	return func(m api.TeamMember) api.CandidateExclusion {
+		switch {
+		case slices.Contains(opts.ExcludeUserIDs, m.UserId):
+			return api.CandidateExclusionExcluded
+		case opts.NewUserID != "" && m.UserId != opts.NewUserID:
+			return api.CandidateExclusionNotRequested
+		case senior != nil:
+			return senior(m)
+		}
+		return ""
+	}
+}
+
+// replacementTargetError explains, from the trace of a failed reassignment,
+// why the requested replacement could not be picked.
+func replacementTargetError(trace *api.AssignmentTrace, target string) error {
+	for i := range trace.Steps {
+		c := traceCandidate(&trace.Steps[i], target)
+		if c == nil {
+			continue
 		}
+		switch c.Excluded {
+		case api.CandidateExclusionAlreadyAssigned:
+			return ErrReviewerAlreadyAssigned
+		case api.CandidateExclusionNotSenior:
+			return ErrNoSeniorReviewer
+		}
+		return ErrReplacementNotEligible
 	}
-	newReviewers = append(newReviewers, newReviewer)
-	pr.AssignedReviewers = newReviewers
+	return ErrReplacementNotAllowed
+}
 
-	err = s.pullRequestRepository.UpdatePR(*pr)
+// seniorReplacementFilter limits the replacement to senior members when the
+// author's team requires a senior reviewer and the outgoing reviewer is the
+// only senior one on the PR.
+func (s *Service) seniorReplacementFilter(pr *api.PullRequest, oldReviewer *api.User) (func(api.TeamMember) api.CandidateExclusion, error) {
+	if !oldReviewer.Seniority.IsSenior() {
+		return nil, nil
+	}
+	author, err := s.findAuthor(pr.AuthorId)
 	if err != nil {
-		s.log.Error("ReassignReviewer: update failed", "pr_id", prID, "old_reviewer", oldReviewerID, "err", err)
-		return nil, nil, err
+		return nil, err
+	}
+	if !s.teamRepository.FindTeamByName(author.TeamName).RequireSenior {
+		return nil, nil
 	}
-	s.log.Info("Reviewer reassigned", "pr_id", prID, "from", oldReviewerID, "to", newReviewer)
 
-	return pr, &newReviewer, nil
+	others := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(id string) bool { return id == oldReviewer.UserId })
+	hasSenior, err := s.hasSeniorReviewer(others)
+	if err != nil || hasSenior {
+		return nil, err
+	}
+	return seniorOnly, nil
 }
 
-func (s *Service) FindPRsByReviewer(userID string) ([]api.PullRequest, error) {
-	return s.pullRequestRepository.FindPRsByReviewer(userID)
+// FindPRsByReviewer lists the PRs the user reviews that pass the filter.
+func (s *Service) FindPRsByReviewer(userID string, filter api.PullRequestFilter) ([]api.PullRequest, error) {
+	return s.pullRequestRepository.FindPRsByReviewer(userID, filter)
 }
 
 func (s *Service) GetStatistics() (*api.Statistics, error) {
@@ -243,9 +1553,13 @@ func (s *Service) GetStatistics() (*api.Statistics, error) {
 		TotalAssignments: 0,
 		ByUser:           make(map[string]int),
 		ByStatus: struct {
+			Draft  int `json:"draft"`
 			Open   int `json:"open"`
 			Merged int `json:"merged"`
+			Closed int `json:"closed"`
 		}{},
+		AverageReviewRoundsByAuthor: make(map[string]float64),
+		ByRepository:                make(map[string]api.RepositoryStatistics),
 	}
 
 	prs, err := s.pullRequestRepository.GetAllPRs()
@@ -254,20 +1568,58 @@ func (s *Service) GetStatistics() (*api.Statistics, error) {
 		return stats, err
 	}
 
+	var reviewed, rounds int
+	authorRounds := make(map[string]int)
+	authorReviewed := make(map[string]int)
+	repoLines := make(map[string]int)
 	for _, pr := range prs {
+		if pr.Repository != "" {
+			repo := stats.ByRepository[pr.Repository]
+			repo.PullRequests++
+			repo.TotalAssignments += len(pr.AssignedReviewers)
+			switch pr.Status {
+			case api.PullRequestStatusOPEN:
+				repo.Open++
+			case api.PullRequestStatusMERGED:
+				repo.Merged++
+			}
+			stats.ByRepository[pr.Repository] = repo
+			repoLines[pr.Repository] += prSize(pr)
+		}
+		if len(pr.AssignedReviewers) > 0 {
+			reviewed++
+			rounds += reviewRound(&pr)
+			authorReviewed[pr.AuthorId]++
+			authorRounds[pr.AuthorId] += reviewRound(&pr)
+		}
+
 		for _, reviewer := range pr.AssignedReviewers {
 			stats.TotalAssignments++
 			stats.ByUser[reviewer]++
 		}
 
 		switch pr.Status {
+		case api.PullRequestStatusDRAFT:
+			stats.ByStatus.Draft++
 		case api.PullRequestStatusOPEN:
 			stats.ByStatus.Open++
 		case api.PullRequestStatusMERGED:
 			stats.ByStatus.Merged++
+		case api.PullRequestStatusCLOSED:
+			stats.ByStatus.Closed++
 		}
 	}
 
+	if reviewed > 0 {
+		stats.AverageReviewRounds = float64(rounds) / float64(reviewed)
+	}
+	for author, count := range authorReviewed {
+		stats.AverageReviewRoundsByAuthor[author] = float64(authorRounds[author]) / float64(count)
+	}
+	for name, repo := range stats.ByRepository {
+		repo.AverageSize = float64(repoLines[name]) / float64(repo.PullRequests)
+		stats.ByRepository[name] = repo
+	}
 	return stats, nil
 }
 
@@ -292,15 +1644,28 @@ func (s *Service) DeactivateUsersAndReassignPRs(teamName string, userIDs []strin
 		userIDMap[userID] = true
 	}
 
-	var activeReplacements []string
+	var activeReplacements []api.TeamMember
+	seniority := make(map[string]api.Seniority)
 	allUsers, err := s.userRepository.GetAllUsers()
 	if err != nil {
 		s.log.Error("DeactivateUsersAndReassignPRs: failed to list users", "team", teamName, "err", err)
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
+	unavailable, err := s.unavailableToday()
+	if err != nil {
+		s.log.Error("DeactivateUsersAndReassignPRs: failed to load unavailable users", "team", teamName, "err", err)
+		return nil, err
+	}
 	for _, user := range allUsers {
-		if user.TeamName == teamName && user.IsActive && !userIDMap[user.UserId] {
-			activeReplacements = append(activeReplacements, user.UserId)
+		seniority[user.UserId] = user.Seniority
+		if user.TeamName == teamName && user.IsActive && !userIDMap[user.UserId] && !unavailable[user.UserId] {
+			activeReplacements = append(activeReplacements, api.TeamMember{
+				UserId:         user.UserId,
+				Username:       user.Username,
+				IsActive:       user.IsActive,
+				MaxOpenReviews: user.MaxOpenReviews,
+				Seniority:      user.Seniority,
+			})
 		}
 	}
 
@@ -309,6 +1674,12 @@ func (s *Service) DeactivateUsersAndReassignPRs(teamName string, userIDs []strin
 		return nil, ErrNoReplacementCandidateInTeam
 	}
 
+	openReviews, err := s.pullRequestRepository.CountOpenReviewsByTeam(teamName)
+	if err != nil {
+		s.log.Error("DeactivateUsersAndReassignPRs: failed to count open reviews", "team", teamName, "err", err)
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+	authorTeams := map[string]api.Team{teamName: team}
 	for _, userID := range userIDs {
 		err := s.userRepository.UpdateUserStatus(userID, false)
 		if err != nil {
@@ -322,7 +1693,7 @@ func (s *Service) DeactivateUsersAndReassignPRs(teamName string, userIDs []strin
 		s.log.Info("User deactivated", "team", teamName, "user", userID)
 		response.DeactivatedCount++
 
-		prs, err := s.pullRequestRepository.FindPRsByReviewer(userID)
+		prs, err := s.pullRequestRepository.FindPRsByReviewer(userID, api.PullRequestFilter{})
 		if err != nil {
 			response.Errors = append(response.Errors, struct {
 				UserID string `json:"user_id"`
@@ -335,30 +1706,101 @@ func (s *Service) DeactivateUsersAndReassignPRs(teamName string, userIDs []strin
 				continue
 			}
 
-			var newReviewers []string
-			for _, rev := range pr.AssignedReviewers {
-				if rev != userID {
-					newReviewers = append(newReviewers, rev)
-				}
+			// The seats, the senior rule and the default limit follow the
+			// author's team, which differs from teamName on PRs reviewed
+			// through CODEOWNERS or fallback teams.
+			authorTeam, err := s.authorTeam(pr.AuthorId, authorTeams)
+			if err != nil {
+				s.log.Error("DeactivateUsersAndReassignPRs: failed to load author team", "pr_id", pr.PullRequestId, "err", err)
+				response.Errors = append(response.Errors, struct {
+					UserID string `json:"user_id"`
+					Error  string `json:"error"`
+				}{UserID: userID, Error: fmt.Sprintf("%v: %s", err, pr.PullRequestId)})
+				continue
 			}
 
-			if len(newReviewers) < 2 && len(activeReplacements) > 0 {
-				replacementIndex, err := randomIndex(len(activeReplacements))
+			removeReviewer(&pr, userID)
+
+			d := s.newDraw(nil, false)
+			d.trace = &api.AssignmentTrace{
+				PullRequestId:  pr.PullRequestId,
+				Action:         api.AssignmentTraceActionReassign,
+				AssignmentSeed: d.seed,
+				At:             s.now(),
+				ReplacedUserId: userID,
+				Steps:          []api.AssignmentTraceStep{},
+			}
+			required := requiredReviewers(authorTeam, &pr)
+			if len(pr.AssignedReviewers) < required {
+				var candidates []api.TeamMember
+				capacityBlocked := false
+				for _, m := range replacementCandidates(activeReplacements, pr.AuthorId, pr.AssignedReviewers) {
+					if hasCapacity(openReviewLimit(m.MaxOpenReviews, authorTeam), openReviews[m.UserId]) {
+						candidates = append(candidates, m)
+					} else {
+						capacityBlocked = true
+					}
+				}
+				assignment := AssignmentContext{
+					PullRequest: pr,
+					TeamName:    teamName,
+					Seats:       required - len(pr.AssignedReviewers),
+					OpenReviews: openReviews,
+					At:          d.trace.At,
+					Rand:        d.rand,
+				}
+				var replacements []string
+				if authorTeam.RequireSenior && !slices.ContainsFunc(pr.AssignedReviewers, func(id string) bool { return seniority[id].IsSenior() }) {
+					step := d.newStep(api.AssignmentTraceStageSenior, teamName, team.AssignmentStrategy, 1)
+					step.Candidates = backfillCandidates(activeReplacements, candidates, pr.AuthorId, pr.AssignedReviewers, openReviews, seniorOnly)
+					senior, err := s.selectReviewers(team, AssignmentContext{
+						PullRequest: pr,
+						TeamName:    teamName,
+						Seats:       1,
+						OpenReviews: openReviews,
+						At:          assignment.At,
+						Rand:        d.rand,
+					}, filterMembers(candidates, seniorOnly), step)
+					if err != nil {
+						return nil, err
+					}
+					step.Picked = append(step.Picked, senior...)
+					d.record(step)
+					if len(senior) == 0 {
+						s.log.Warn("DeactivateUsersAndReassignPRs: no senior reviewer available", "pr_id", pr.PullRequestId)
+						response.Errors = append(response.Errors, struct {
+							UserID string `json:"user_id"`
+							Error  string `json:"error"`
+						}{UserID: userID, Error: fmt.Sprintf("%s: %s", api.NOSENIOR, pr.PullRequestId)})
+					}
+					replacements = senior
+					assignment.Seats -= len(senior)
+				}
+				step := d.newStep(api.AssignmentTraceStageFill, teamName, team.AssignmentStrategy, assignment.Seats)
+				rest := replacementCandidates(candidates, pr.AuthorId, replacements)
+				step.Candidates = backfillCandidates(activeReplacements, rest, pr.AuthorId, append(slices.Clone(pr.AssignedReviewers), replacements...), openReviews, nil)
+				picked, err := s.selectReviewers(team, assignment, rest, step)
 				if err != nil {
+					return nil, err
+				}
+				step.Picked = append(step.Picked, picked...)
+				d.record(step)
+				replacements = append(replacements, picked...)
+				for _, replacement := range replacements {
+					addReviewers(&pr, api.ReviewerAssignment{UserId: replacement, TeamName: teamName, AssignmentSeed: &d.seed, Source: api.ReviewerSourceAuto})
+					openReviews[replacement]++
+				}
+				if len(pr.AssignedReviewers) < required && capacityBlocked {
+					s.log.Warn("DeactivateUsersAndReassignPRs: capacity blocks backfill", "pr_id", pr.PullRequestId)
 					response.Errors = append(response.Errors, struct {
 						UserID string `json:"user_id"`
 						Error  string `json:"error"`
-					}{UserID: userID, Error: fmt.Sprintf("random selection failed: %v", err)})
-					s.log.Error("DeactivateUsersAndReassignPRs: randomIndex failed", "team", teamName, "user", userID, "err", err)
-					continue
+					}{UserID: userID, Error: fmt.Sprintf("%s: %s", api.CAPACITYEXHAUSTED, pr.PullRequestId)})
 				}
-				replacement := activeReplacements[replacementIndex]
-				newReviewers = append(newReviewers, replacement)
 			}
+			d.trace.Reviewers = slices.Clone(pr.AssignedReviewers)
 
-			pr.AssignedReviewers = newReviewers
-			err := s.pullRequestRepository.UpdatePR(pr)
-			if err != nil {
+			if err := s.pullRequestRepository.UpdatePR(pr, d.trace); err != nil {
 				s.log.Error("DeactivateUsersAndReassignPRs: failed to update PR", "pr_id", pr.PullRequestId, "err", err)
 				return nil, err
 			}
@@ -369,3 +1811,211 @@ func (s *Service) DeactivateUsersAndReassignPRs(teamName string, userIDs []strin
 	s.log.Info("DeactivateUsersAndReassignPRs finished", "team", teamName, "deactivated", response.DeactivatedCount, "reassigned", response.ReassignedCount)
 	return response, nil
 }
+
+// ReassignOpenReviews moves every open review of the user to another reviewer
+// using the regular reassignment rules. PRs that cannot be reassigned are
+// reported with their error and keep the user as reviewer.
+func (s *Service) ReassignOpenReviews(userID string) ([]api.ReviewReassignment, error) {
+	prs, err := s.pullRequestRepository.FindPRsByReviewer(userID, api.PullRequestFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	results := make([]api.ReviewReassignment, 0, len(prs))
+	for _, pr := range prs {
+		if pr.Status != api.PullRequestStatusOPEN {
+			continue
+		}
+
+		result := api.ReviewReassignment{PullRequestId: pr.PullRequestId}
+		if _, replacedBy, _, err := s.ReassignReviewer(pr.PullRequestId, userID, api.ReassignOptions{}); err != nil {
+			s.log.Warn("ReassignOpenReviews: reassignment failed", "pr_id", pr.PullRequestId, "user", userID, "err", err)
+			result.Error = err.Error()
+		} else {
+			result.ReplacedBy = replacedBy
+		}
+		results = append(results, result)
+	}
+	s.log.Info("ReassignOpenReviews finished", "user", userID, "prs", len(results))
+	return results, nil
+}
+
+// HandOverReviews reassigns the user's open reviews for an unavailability
+// range that is under way or starts within handoverLeadDays. For ranges
+// further ahead or already over nothing is moved and the result is nil.
+func (s *Service) HandOverReviews(unavailability api.Unavailability) ([]api.ReviewReassignment, error) {
+	now := s.now()
+	today := now.Format(time.DateOnly)
+	soon := now.AddDate(0, 0, handoverLeadDays).Format(time.DateOnly)
+	if unavailability.StartDate > soon || unavailability.EndDate < today {
+		s.log.Info("HandOverReviews: range not current, reviews kept", "user", unavailability.UserId, "start", unavailability.StartDate, "end", unavailability.EndDate)
+		return nil, nil
+	}
+	return s.ReassignOpenReviews(unavailability.UserId)
+}
+
+// DeactivateUser marks the user inactive and, when reassignReviews is set,
+// moves their open reviews to other team members. The deactivation is
+// committed first; if the reviews cannot be listed afterwards the user is
+// returned with ReassignError set instead of failing the call.
+func (s *Service) DeactivateUser(userID string, reassignReviews bool) (*api.UserDeactivation, error) {
+	user, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		s.log.Error("DeactivateUser: user not found", "user_id", userID, "err", err)
+		return nil, ErrUserNotFound
+	}
+	if err := s.userRepository.UpdateUserStatus(userID, false); err != nil {
+		s.log.Error("DeactivateUser: failed to deactivate user", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("update user status: %w", err)
+	}
+	user.IsActive = false
+	s.log.Info("DeactivateUser: user deactivated", "user_id", userID)
+
+	result := &api.UserDeactivation{User: user}
+	if !reassignReviews {
+		return result, nil
+	}
+	reassigned, err := s.ReassignOpenReviews(userID)
+	if err != nil {
+		s.log.Error("DeactivateUser: open reviews not reassigned", "user_id", userID, "err", err)
+		result.ReassignError = "open reviews could not be listed"
+		return result, nil
+	}
+	result.Reassigned = reassigned
+	return result, nil
+}
+
+// GetPairingMatrix counts, for every author of the team, how many of their PRs
+// each reviewer reviewed within the lookback window. A nil lookbackDays uses
+// the team's pairing_lookback_days.
+func (s *Service) GetPairingMatrix(teamName string, lookbackDays *int) (*api.PairingMatrix, error) {
+	team := s.teamRepository.FindTeamByName(teamName)
+	if team.TeamName == "" {
+		return nil, ErrTeamNotFound
+	}
+
+	days := pairingLookbackDays(team)
+	if lookbackDays != nil {
+		if *lookbackDays <= 0 {
+			return nil, ErrInvalidLookback
+		}
+		days = *lookbackDays
+	}
+	since := s.now().AddDate(0, 0, -days)
+
+	matrix, err := s.pullRequestRepository.FindPairingsByTeam(teamName, since)
+	if err != nil {
+		s.log.Error("GetPairingMatrix: failed to load pairings", "team", teamName, "err", err)
+		return nil, fmt.Errorf("failed to load pairings: %w", err)
+	}
+	return &api.PairingMatrix{TeamName: teamName, LookbackDays: days, Since: since, Matrix: matrix}, nil
+}
+
+// GetAssignmentTraces returns the stored assignment traces of the PR, oldest
+// first.
+func (s *Service) GetAssignmentTraces(prID string) ([]api.AssignmentTrace, error) {
+	if _, err := s.findPR(prID); err != nil {
+		return nil, err
+	}
+	traces, err := s.pullRequestRepository.FindAssignmentTraces(prID)
+	if err != nil {
+		s.log.Error("GetAssignmentTraces: failed to load traces", "pr_id", prID, "err", err)
+		return nil, fmt.Errorf("failed to load assignment traces: %w", err)
+	}
+	return traces, nil
+}
+
+func replacementCandidates(members []api.TeamMember, authorID string, assigned []string) []api.TeamMember {
+	taken := make(map[string]bool, len(assigned)+1)
+	taken[authorID] = true
+	for _, reviewer := range assigned {
+		taken[reviewer] = true
+	}
+
+	candidates := make([]api.TeamMember, 0, len(members))
+	for _, m := range members {
+		if !taken[m.UserId] {
+			candidates = append(candidates, m)
+		}
+	}
+	return candidates
+}
+
+// addReviewers assigns reviewers to the PR, keeping AssignedReviewers and
+// Reviewers in sync.
+func addReviewers(pr *api.PullRequest, reviewers ...api.ReviewerAssignment) {
+	for _, r := range reviewers {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, r.UserId)
+		pr.Reviewers = append(pr.Reviewers, r)
+	}
+}
+
+func removeReviewer(pr *api.PullRequest, userID string) {
+	assigned := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		if id != userID {
+			assigned = append(assigned, id)
+		}
+	}
+	pr.AssignedReviewers = assigned
+
+	reviewers := make([]api.ReviewerAssignment, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		if r.UserId != userID {
+			reviewers = append(reviewers, r)
+		}
+	}
+	pr.Reviewers = reviewers
+}
+
+// seniorOnly rules out members below senior.
+func seniorOnly(m api.TeamMember) api.CandidateExclusion {
+	if m.Seniority.IsSenior() {
+		return ""
+	}
+	return api.CandidateExclusionNotSenior
+}
+
+// filterMembers returns the members exclude does not rule out; a nil exclude
+// keeps all.
+func filterMembers(members []api.TeamMember, exclude func(api.TeamMember) api.CandidateExclusion) []api.TeamMember {
+	if exclude == nil {
+		return members
+	}
+	var kept []api.TeamMember
+	for _, m := range members {
+		if exclude(m) == "" {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// uncoveredSkills returns the skills that are not in have.
+func uncoveredSkills(skills, have []string) []string {
+	var missing []string
+	for _, skill := range skills {
+		if !slices.Contains(have, skill) {
+			missing = append(missing, skill)
+		}
+	}
+	return missing
+}
+
+// assignedReviewers returns the PR's current reviewers as assignments, for
+// the selection stages that only look at who is already chosen.
+func assignedReviewers(pr *api.PullRequest) []api.ReviewerAssignment {
+	reviewers := make([]api.ReviewerAssignment, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		reviewers = append(reviewers, api.ReviewerAssignment{UserId: id})
+	}
+	return reviewers
+}
+
+func reviewerIDs(reviewers []api.ReviewerAssignment) []string {
+	ids := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		ids = append(ids, r.UserId)
+	}
+	return ids
+}
@@ -1,15 +1,28 @@
 package pullrequest
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
+	"github.com/V1merX/pr-reviewer-service/internal/repository"
+	"github.com/V1merX/pr-reviewer-service/internal/service"
 )
 
 type fakeUserRepo struct {
-	users map[string]api.User
+	users        map[string]api.User
+	skills       map[string][]string
+	unavailable  map[string]bool
+	workingHours map[string]api.WorkingHours
+	deactivated  []string
 }
 
 func (f *fakeUserRepo) FindUserByID(userID string) (*api.User, error) {
@@ -19,46 +32,235 @@ func (f *fakeUserRepo) FindUserByID(userID string) (*api.User, error) {
 	}
 	return &u, nil
 }
-func (f *fakeUserRepo) UpdateUserStatus(userID string, status bool) error { return nil }
-func (f *fakeUserRepo) GetAllUsers() ([]api.User, error)                  { return nil, nil }
+func (f *fakeUserRepo) UpdateUserStatus(userID string, status bool) error {
+	if !status {
+		f.deactivated = append(f.deactivated, userID)
+	}
+	return nil
+}
+func (f *fakeUserRepo) UpdateUserMaxOpenReviews(userID string, limit *int) error { return nil }
+func (f *fakeUserRepo) UpdateUserSeniority(userID string, seniority api.Seniority) error {
+	return nil
+}
+func (f *fakeUserRepo) GetAllUsers() ([]api.User, error) {
+	return slices.Collect(maps.Values(f.users)), nil
+}
+func (f *fakeUserRepo) SetUserSkills(userID string, skills []string) error { return nil }
+func (f *fakeUserRepo) FindSkillsByUsers(userIDs []string) (map[string][]string, error) {
+	return f.skills, nil
+}
+func (f *fakeUserRepo) CreateUnavailability(u api.Unavailability) (int64, error) { return 0, nil }
+func (f *fakeUserRepo) FindUnavailabilityByID(id int64) (*api.Unavailability, error) {
+	return nil, repositoryError("not found")
+}
+func (f *fakeUserRepo) FindUnavailabilityByUser(userID string) ([]api.Unavailability, error) {
+	return nil, nil
+}
+func (f *fakeUserRepo) UpdateUnavailability(u api.Unavailability) error { return nil }
+func (f *fakeUserRepo) DeleteUnavailability(id int64) error             { return nil }
+func (f *fakeUserRepo) UpdateUserWorkingHours(userID string, hours *api.WorkingHours) error {
+	return nil
+}
+func (f *fakeUserRepo) FindWorkingHoursByUsers(userIDs []string) (map[string]api.WorkingHours, error) {
+	return f.workingHours, nil
+}
+func (f *fakeUserRepo) FindUnavailableUserIDs(day string) (map[string]bool, error) {
+	return f.unavailable, nil
+}
 
 type fakeTeamRepo struct {
 	members map[string][]api.TeamMember
+	teams   map[string]api.Team
 }
 
-func (f *fakeTeamRepo) CreateTeam(team api.Team) error                  { return nil }
-func (f *fakeTeamRepo) UpdateTeam(team api.Team) error                  { return nil }
-func (f *fakeTeamRepo) ExistTeamByName(name string) bool                { return false }
-func (f *fakeTeamRepo) FindTeamByName(name string) api.Team             { return api.Team{} }
+func (f *fakeTeamRepo) CreateTeam(team api.Team) error         { return nil }
+func (f *fakeTeamRepo) UpdateTeam(team api.Team) error         { return nil }
+func (f *fakeTeamRepo) UpdateTeamSettings(team api.Team) error { return nil }
+func (f *fakeTeamRepo) ExistTeamByName(name string) bool {
+	_, ok := f.teams[name]
+	return ok
+}
+func (f *fakeTeamRepo) FindTeamByName(name string) api.Team             { return f.teams[name] }
 func (f *fakeTeamRepo) FindTeamsByUser(userID string) ([]string, error) { return nil, nil }
 func (f *fakeTeamRepo) FindTeamMembersByName(teamName string) ([]api.TeamMember, error) {
 	return f.members[teamName], nil
 }
 
 type fakePRRepo struct {
+	prs           map[string]api.PullRequest
 	created       []api.PullRequest
 	prsByReviewer map[string][]api.PullRequest
 	updated       []api.PullRequest
+	findErr       error
+	reviewerErr   error
+	// reviewerFilter is the filter of the last FindPRsByReviewer call.
+	reviewerFilter api.PullRequestFilter
+	openReviews    map[string]int
+	pairings       map[string]map[string]int
+	pairingsSince  time.Time
+	traces         []api.AssignmentTrace
+	listQuery      api.PullRequestListQuery
+	reviews        map[string][]api.Review
+	openByTeam     map[string][]api.PullRequest
+	moved          []api.RebalanceMove
+	writeErr       error
 }
 
-func (f *fakePRRepo) CreatePR(pr api.PullRequest) error {
+func (f *fakePRRepo) CreatePR(pr api.PullRequest, trace *api.AssignmentTrace) error {
 	f.created = append(f.created, pr)
+	f.saveTrace(trace)
 	return nil
 }
 
 func (f *fakePRRepo) FindPRByID(prID string) (*api.PullRequest, error) {
-	return nil, repositoryError("not found")
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	pr, ok := f.prs[prID]
+	if !ok {
+		return nil, repository.ErrPRNotFound
+	}
+	return &pr, nil
+}
+
+func (f *fakePRRepo) UpdatePR(pr api.PullRequest, trace *api.AssignmentTrace) error {
+	f.updated = append(f.updated, pr)
+	if f.prs != nil {
+		f.prs[pr.PullRequestId] = pr
+	}
+	f.saveTrace(trace)
+	return nil
 }
 
-func (f *fakePRRepo) UpdatePR(pr api.PullRequest) error {
+func (f *fakePRRepo) FindOpenPRsByTeamReviewers(teamName string) ([]api.PullRequest, error) {
+	return f.openByTeam[teamName], nil
+}
+
+// AddReviewer re-checks the stored PR like the real repository does.
+func (f *fakePRRepo) AddReviewer(prID string, expected []string, reviewer api.ReviewerAssignment, trace *api.AssignmentTrace) error {
+	pr, err := f.expectReviewers(prID, expected)
+	if err != nil {
+		return err
+	}
+	addReviewers(&pr, reviewer)
+	f.prs[prID] = pr
+	f.updated = append(f.updated, pr)
+	f.saveTrace(trace)
+	return nil
+}
+
+func (f *fakePRRepo) RemoveReviewer(prID string, expected []string, userID string, trace *api.AssignmentTrace) error {
+	pr, err := f.expectReviewers(prID, expected)
+	if err != nil {
+		return err
+	}
+	removeReviewer(&pr, userID)
+	f.prs[prID] = pr
 	f.updated = append(f.updated, pr)
+	f.saveTrace(trace)
 	return nil
 }
 
-func (f *fakePRRepo) FindPRsByReviewer(userID string) ([]api.PullRequest, error) {
+func (f *fakePRRepo) expectReviewers(prID string, expected []string) (api.PullRequest, error) {
+	pr := f.prs[prID]
+	if f.writeErr != nil {
+		return pr, f.writeErr
+	}
+	if pr.Status != api.PullRequestStatusOPEN || !slices.Equal(pr.AssignedReviewers, expected) {
+		return pr, repository.ErrPRChanged
+	}
+	return pr, nil
+}
+
+func (f *fakePRRepo) MoveReviews(teamName string, moves []api.RebalanceMove, traces []api.AssignmentTrace) error {
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	f.moved = append(f.moved, moves...)
+	f.traces = append(f.traces, traces...)
+	return nil
+}
+
+func (f *fakePRRepo) FindPRsByReviewer(userID string, filter api.PullRequestFilter) ([]api.PullRequest, error) {
+	f.reviewerFilter = filter
+	if f.reviewerErr != nil {
+		return nil, f.reviewerErr
+	}
 	return f.prsByReviewer[userID], nil
 }
-func (f *fakePRRepo) GetAllPRs() ([]api.PullRequest, error) { return nil, nil }
+func (f *fakePRRepo) GetAllPRs() ([]api.PullRequest, error) {
+	return slices.Collect(maps.Values(f.prs)), nil
+}
+
+// ListPRs orders by pull_request_id only, which is enough to page through.
+func (f *fakePRRepo) ListPRs(query api.PullRequestListQuery, after *api.PullRequestCursor) ([]api.PullRequest, error) {
+	f.listQuery = query
+	ids := slices.Sorted(maps.Keys(f.prs))
+	var prs []api.PullRequest
+	for _, id := range ids {
+		pr := f.prs[id]
+		if (query.Status != "" && pr.Status != query.Status) || (query.AuthorId != "" && pr.AuthorId != query.AuthorId) {
+			continue
+		}
+		if after != nil && id <= after.PullRequestId {
+			continue
+		}
+		if len(prs) == query.Limit {
+			break
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+func (f *fakePRRepo) CountOpenReviewsByTeam(teamName string) (map[string]int, error) {
+	counts := make(map[string]int, len(f.openReviews))
+	for userID, n := range f.openReviews {
+		counts[userID] = n
+	}
+	return counts, nil
+}
+
+func (f *fakePRRepo) CountPairingsByAuthor(authorID string, since time.Time) (map[string]int, error) {
+	f.pairingsSince = since
+	return f.pairings[authorID], nil
+}
+func (f *fakePRRepo) FindPairingsByTeam(teamName string, since time.Time) (map[string]map[string]int, error) {
+	f.pairingsSince = since
+	return f.pairings, nil
+}
+
+func (f *fakePRRepo) saveTrace(trace *api.AssignmentTrace) {
+	if trace != nil {
+		f.traces = append(f.traces, *trace)
+	}
+}
+func (f *fakePRRepo) FindAssignmentTraces(prID string) ([]api.AssignmentTrace, error) {
+	return f.traces, nil
+}
+func (f *fakePRRepo) SaveReview(review api.Review) error {
+	if f.reviews == nil {
+		f.reviews = make(map[string][]api.Review)
+	}
+	reviews := slices.DeleteFunc(f.reviews[review.PullRequestId], func(r api.Review) bool { return r.UserId == review.UserId && r.Round == review.Round })
+	f.reviews[review.PullRequestId] = append(reviews, review)
+	return nil
+}
+func (f *fakePRRepo) FindReviews(prID string) ([]api.Review, error) {
+	return f.reviews[prID], nil
+}
+
+type fakeCodeOwnersRepo struct {
+	rules map[string][]api.CodeOwnersRule
+}
+
+func (f *fakeCodeOwnersRepo) ReplaceRules(repository string, rules []api.CodeOwnersRule) error {
+	return nil
+}
+
+func (f *fakeCodeOwnersRepo) FindRules(repository string) ([]api.CodeOwnersRule, error) {
+	return f.rules[repository], nil
+}
 
 type repositoryError string
 
@@ -66,7 +268,7 @@ func (e repositoryError) Error() string { return string(e) }
 
 func TestSelectRandomReviewers_Table(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	svc := NewService(logger, nil, nil, nil)
+	svc := NewService(logger, nil, nil, nil, nil)
 
 	cases := []struct {
 		name    string
@@ -81,7 +283,7 @@ func TestSelectRandomReviewers_Table(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			got := svc.SelectRandomReviewers(tc.members, tc.count)
+			got := svc.SelectRandomReviewers(tc.members, tc.count, false)
 			if len(got) != tc.wantLen {
 				t.Fatalf("want len %d got %d", tc.wantLen, len(got))
 			}
@@ -89,16 +291,37 @@ func TestSelectRandomReviewers_Table(t *testing.T) {
 	}
 }
 
+func TestSelectRandomReviewers_RequireSenior(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, nil, nil, nil, nil)
+
+	members := []api.TeamMember{
+		{UserId: "j1", Seniority: api.SeniorityJunior},
+		{UserId: "j2", Seniority: api.SeniorityJunior},
+		{UserId: "m1", Seniority: api.SeniorityMid},
+		{UserId: "s1", Seniority: api.SenioritySenior},
+	}
+	for i := 0; i < 20; i++ {
+		got := svc.SelectRandomReviewers(members, 2, true)
+		if len(got) != 2 || got[0] != "s1" {
+			t.Fatalf("expected the senior first and one more reviewer, got %v", got)
+		}
+	}
+	if got := svc.SelectRandomReviewers(members[:3], 2, true); len(got) != 2 {
+		t.Fatalf("expected random fill without seniors, got %v", got)
+	}
+}
+
 func TestCreatePR_Success(t *testing.T) {
 	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
 	trepo := &fakeTeamRepo{members: map[string][]api.TeamMember{"team1": {{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}}}}
 	prrepo := &fakePRRepo{}
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	svc := NewService(logger, prrepo, trepo, urepo)
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
 
 	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
-	if err := svc.CreatePR(pr); err != nil {
+	if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
 		t.Fatalf("CreatePR failed: %v", err)
 	}
 	if len(prrepo.created) != 1 {
@@ -112,3 +335,2400 @@ func TestCreatePR_Success(t *testing.T) {
 		t.Fatalf("expected assigned reviewers")
 	}
 }
+
+func TestSelectLeastLoadedReviewers_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, nil, nil, nil, nil)
+
+	members := []api.TeamMember{{UserId: "a"}, {UserId: "b"}, {UserId: "c"}, {UserId: "d"}}
+
+	cases := []struct {
+		name        string
+		openReviews map[string]int
+		count       int
+		want        map[string]bool
+	}{
+		{"two least loaded", map[string]int{"a": 8, "b": 0, "c": 3, "d": 1}, 2, map[string]bool{"b": true, "d": true}},
+		{"unknown users count as idle", map[string]int{"a": 2, "b": 2, "c": 2}, 1, map[string]bool{"d": true}},
+		{"single pick", map[string]int{"a": 1, "b": 5, "c": 5, "d": 5}, 1, map[string]bool{"a": true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := svc.SelectLeastLoadedReviewers(members, tc.openReviews, tc.count)
+			if len(got) != len(tc.want) {
+				t.Fatalf("want %d reviewers got %d", len(tc.want), len(got))
+			}
+			for _, id := range got {
+				if !tc.want[id] {
+					t.Fatalf("unexpected reviewer %s in %v", id, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCreatePR_PrefersLeastLoaded(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{members: map[string][]api.TeamMember{"team1": {
+		{UserId: "author", IsActive: true},
+		{UserId: "busy", IsActive: true},
+		{UserId: "free1", IsActive: true},
+		{UserId: "free2", IsActive: true},
+	}}}
+	prrepo := &fakePRRepo{openReviews: map[string]int{"busy": 8, "free1": 0, "free2": 1}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+	if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	for _, reviewer := range pr.AssignedReviewers {
+		if reviewer == "busy" || reviewer == "author" {
+			t.Fatalf("unexpected reviewer %s in %v", reviewer, pr.AssignedReviewers)
+		}
+	}
+}
+
+func TestAssignmentStrategies_Order(t *testing.T) {
+	candidates := []api.TeamMember{{UserId: "a"}, {UserId: "b"}, {UserId: "c"}}
+	assignment := AssignmentContext{TeamName: "team1", Seats: 2, OpenReviews: map[string]int{"a": 4, "b": 0, "c": 1}}
+
+	for name, strategy := range newStrategies() {
+		t.Run(string(name), func(t *testing.T) {
+			got := strategy.Order(assignment, candidates)
+			if len(got) != len(candidates) {
+				t.Fatalf("want %d ids got %v", len(candidates), got)
+			}
+			seen := make(map[string]bool)
+			for _, id := range got {
+				if seen[id] {
+					t.Fatalf("duplicate id %s in %v", id, got)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestRoundRobinStrategy_Rotates(t *testing.T) {
+	strategy := &roundRobinStrategy{last: make(map[string]string)}
+	candidates := []api.TeamMember{{UserId: "c"}, {UserId: "a"}, {UserId: "b"}}
+	assignment := AssignmentContext{TeamName: "team1", Seats: 2}
+
+	want := [][]string{{"a", "b"}, {"c", "a"}, {"b", "c"}}
+	for i, w := range want {
+		got := strategy.Order(assignment, candidates)[:2]
+		if got[0] != w[0] || got[1] != w[1] {
+			t.Fatalf("round %d: want %v got %v", i, w, got)
+		}
+		strategy.Picked(assignment, got)
+	}
+}
+
+func TestRoundRobinStrategy_DelaysRecentPairings(t *testing.T) {
+	strategy := &roundRobinStrategy{last: make(map[string]string)}
+	candidates := []api.TeamMember{{UserId: "a"}, {UserId: "b"}, {UserId: "c"}, {UserId: "d"}}
+	assignment := AssignmentContext{TeamName: "team1", Seats: 2, RecentPairings: map[string]int{"a": 2}}
+
+	got := strategy.Order(assignment, candidates)[:2]
+	if !slices.Equal(got, []string{"b", "a"}) {
+		t.Fatalf("want a delayed by two seats to [b a], got %v", got)
+	}
+	strategy.Picked(assignment, got)
+	if next := strategy.Order(AssignmentContext{TeamName: "team1", Seats: 2}, candidates)[:2]; !slices.Equal(next, []string{"c", "d"}) {
+		t.Fatalf("want the rotation to continue after b, got %v", next)
+	}
+}
+
+func TestCreatePR_UsesTeamStrategy(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}, {UserId: "u3", IsActive: true}}},
+		teams:   map[string]api.Team{"team1": {TeamName: "team1", AssignmentStrategy: api.TeamAssignmentStrategyRoundRobin}},
+	}
+	prrepo := &fakePRRepo{}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+	if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u1" || pr.AssignedReviewers[1] != "u2" {
+		t.Fatalf("expected round-robin reviewers [u1 u2], got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestCreatePR_RespectsRequiredReviewers(t *testing.T) {
+	members := []api.TeamMember{{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}, {UserId: "u3", IsActive: true}, {UserId: "u4", IsActive: true}}
+
+	cases := []struct {
+		name     string
+		required int
+		want     int
+	}{
+		{"default", 0, 2},
+		{"docs team", 1, 1},
+		{"platform team", 3, 3},
+		{"more than available", 6, 4},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"team1": members},
+				teams:   map[string]api.Team{"team1": {TeamName: "team1", RequiredReviewers: tc.required}},
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, &fakePRRepo{}, trepo, urepo, nil)
+
+			pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+			if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+				t.Fatalf("CreatePR failed: %v", err)
+			}
+			if len(pr.AssignedReviewers) != tc.want {
+				t.Fatalf("want %d reviewers got %v", tc.want, pr.AssignedReviewers)
+			}
+		})
+	}
+}
+
+func TestCreatePR_RoutesToCodeOwners(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend"},
+		"alice":  {UserId: "alice", TeamName: "security", IsActive: true},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+			"dba":     {{UserId: "d1", IsActive: true}},
+		},
+		teams: map[string]api.Team{
+			"backend": {TeamName: "backend", RequiredReviewers: 3},
+			"dba":     {TeamName: "dba"},
+		},
+	}
+	corepo := &fakeCodeOwnersRepo{rules: map[string][]api.CodeOwnersRule{
+		"svc": {{Pattern: "*.sql", Owners: []string{"@dba"}}, {Pattern: "/internal/auth/", Owners: []string{"@alice"}}},
+	}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, &fakePRRepo{}, trepo, urepo, corepo)
+
+	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+	opts := api.CreatePROptions{Repository: "svc", ChangedFiles: []string{"migrations/001.sql", "internal/auth/token.go"}}
+	if _, err := svc.CreatePR(pr, opts); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 3 {
+		t.Fatalf("want 3 reviewers got %v", pr.AssignedReviewers)
+	}
+	if pr.AssignedReviewers[0] != "d1" || pr.AssignedReviewers[1] != "alice" {
+		t.Fatalf("expected code owners first, got %v", pr.AssignedReviewers)
+	}
+	if pr.AssignedReviewers[2] != "b1" && pr.AssignedReviewers[2] != "b2" {
+		t.Fatalf("expected remaining seat from author's team, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestCreatePR_FallsBackToOtherTeams(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "mobile"}}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"mobile":   {{UserId: "author", IsActive: true}, {UserId: "m1", IsActive: false}},
+			"web":      {{UserId: "w1", IsActive: true}},
+			"platform": {{UserId: "p1", IsActive: true}, {UserId: "p2", IsActive: true}},
+		},
+		teams: map[string]api.Team{
+			"mobile":   {TeamName: "mobile", FallbackTeams: []string{"web", "platform"}},
+			"web":      {TeamName: "web"},
+			"platform": {TeamName: "platform"},
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, &fakePRRepo{}, trepo, urepo, nil)
+
+	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+	if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if len(pr.Reviewers) != 2 {
+		t.Fatalf("want 2 reviewers got %v", pr.Reviewers)
+	}
+	if first := pr.Reviewers[0]; first.UserId != "w1" || first.TeamName != "web" {
+		t.Fatalf("expected first fallback team to be used first, got %v", pr.Reviewers)
+	}
+	if pr.Reviewers[1].TeamName != "platform" {
+		t.Fatalf("expected second seat from platform, got %v", pr.Reviewers)
+	}
+}
+
+func TestReassignReviewer_FallsBackToOtherTeams(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend"},
+		"b1":     {UserId: "b1", TeamName: "backend"},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}},
+			"sre":     {{UserId: "s1", IsActive: true}},
+		},
+		teams: map[string]api.Team{
+			"backend": {TeamName: "backend", FallbackTeams: []string{"sre"}},
+			"sre":     {TeamName: "sre"},
+		},
+	}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+		PullRequestId:     "pr1",
+		AuthorId:          "author",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"b1"},
+		Reviewers:         []api.ReviewerAssignment{{UserId: "b1", TeamName: "backend"}},
+	}}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr, replacedBy, _, err := svc.ReassignReviewer("pr1", "b1", api.ReassignOptions{})
+	if err != nil {
+		t.Fatalf("ReassignReviewer failed: %v", err)
+	}
+	if replacedBy == nil || *replacedBy != "s1" {
+		t.Fatalf("expected s1 from fallback team, got %v", replacedBy)
+	}
+	if len(pr.Reviewers) != 1 || pr.Reviewers[0].TeamName != "sre" {
+		t.Fatalf("expected reviewer from sre, got %v", pr.Reviewers)
+	}
+}
+
+func TestCreatePR_MatchesRequiredSkills(t *testing.T) {
+	members := []api.TeamMember{
+		{UserId: "author", IsActive: true},
+		{UserId: "u1", IsActive: true},
+		{UserId: "u2", IsActive: true},
+		{UserId: "u3", IsActive: true},
+		{UserId: "u4", IsActive: true},
+	}
+	skills := map[string][]string{"u2": {"go"}, "u3": {"sql"}, "u4": {"go", "sql"}}
+
+	cases := []struct {
+		name     string
+		required []string
+		mode     api.SkillMatchMode
+		want     []string
+		wantErr  error
+	}{
+		{"single tag", []string{"sql"}, "", []string{"u3", "u4"}, nil},
+		{"one reviewer covers both", []string{"go", "sql"}, api.SkillMatchModeRequire, []string{"u4"}, nil},
+		{"prefer uncovered", []string{"frontend"}, api.SkillMatchModePrefer, nil, nil},
+		{"require uncovered", []string{"frontend"}, api.SkillMatchModeRequire, nil, ErrSkillsNotCovered},
+		{"unknown mode", []string{"go"}, "any", nil, ErrInvalidSkillMatch},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}, skills: skills}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"team1": members},
+				teams:   map[string]api.Team{"team1": {TeamName: "team1"}},
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, &fakePRRepo{}, trepo, urepo, nil)
+
+			pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+			_, err := svc.CreatePR(pr, api.CreatePROptions{RequiredSkills: tc.required, SkillMatch: tc.mode})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreatePR failed: %v", err)
+			}
+			if len(pr.AssignedReviewers) != 2 {
+				t.Fatalf("want 2 reviewers got %v", pr.AssignedReviewers)
+			}
+			if tc.want != nil && !slices.Contains(tc.want, pr.AssignedReviewers[0]) {
+				t.Fatalf("expected first reviewer in %v, got %v", tc.want, pr.AssignedReviewers)
+			}
+		})
+	}
+}
+
+func TestCreatePR_RespectsCapacity(t *testing.T) {
+	one, three := 1, 3
+
+	cases := []struct {
+		name        string
+		teamLimit   *int
+		memberLimit *int
+		openReviews map[string]int
+		want        []string
+		shortfall   int
+		capacity    bool
+	}{
+		{"no limits", nil, nil, map[string]int{"u1": 5, "u2": 5}, []string{"u1", "u2"}, 0, false},
+		{"team default excludes busy member", &one, nil, map[string]int{"u1": 1}, []string{"u2"}, 1, true},
+		{"member override beats team default", &one, &three, map[string]int{"u1": 2}, []string{"u1", "u2"}, 0, false},
+		{"everyone at capacity", &one, nil, map[string]int{"u1": 1, "u2": 1}, nil, 2, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"team1": {
+					{UserId: "author", IsActive: true},
+					{UserId: "u1", IsActive: true, MaxOpenReviews: tc.memberLimit},
+					{UserId: "u2", IsActive: true},
+				}},
+				teams: map[string]api.Team{"team1": {TeamName: "team1", MaxOpenReviews: tc.teamLimit}},
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, &fakePRRepo{openReviews: tc.openReviews}, trepo, urepo, nil)
+
+			members, err := svc.GetActiveTeamMembers("author")
+			if err != nil {
+				t.Fatalf("GetActiveTeamMembers failed: %v", err)
+			}
+			if got := memberIDs(members); !slices.Equal(got, tc.want) && !(len(got) == 0 && tc.want == nil) {
+				t.Fatalf("want available members %v got %v", tc.want, got)
+			}
+
+			pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+			trace, err := svc.CreatePR(pr, api.CreatePROptions{})
+			if err != nil {
+				t.Fatalf("CreatePR failed: %v", err)
+			}
+			if len(pr.AssignedReviewers) != 2-tc.shortfall {
+				t.Fatalf("want %d reviewers got %v", 2-tc.shortfall, pr.AssignedReviewers)
+			}
+			if trace.Shortfall != tc.shortfall {
+				t.Fatalf("want shortfall %d got %d", tc.shortfall, trace.Shortfall)
+			}
+			if trace.CapacityExhausted != tc.capacity {
+				t.Fatalf("want capacity exhausted %v got %v", tc.capacity, trace.CapacityExhausted)
+			}
+		})
+	}
+}
+
+func TestReassignReviewer_CapacityExhausted(t *testing.T) {
+	one := 1
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend"},
+		"b1":     {UserId: "b1", TeamName: "backend"},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+		},
+		teams: map[string]api.Team{"backend": {TeamName: "backend", MaxOpenReviews: &one}},
+	}
+	prrepo := &fakePRRepo{
+		prs: map[string]api.PullRequest{"pr1": {
+			PullRequestId:     "pr1",
+			AuthorId:          "author",
+			Status:            api.PullRequestStatusOPEN,
+			AssignedReviewers: []string{"b1"},
+		}},
+		openReviews: map[string]int{"b1": 1, "b2": 1},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	if _, _, _, err := svc.ReassignReviewer("pr1", "b1", api.ReassignOptions{}); !errors.Is(err, ErrCapacityExhausted) {
+		t.Fatalf("want %v got %v", ErrCapacityExhausted, err)
+	}
+}
+
+func TestCreatePR_SkipsUnavailableMembers(t *testing.T) {
+	urepo := &fakeUserRepo{
+		users:       map[string]api.User{"author": {UserId: "author", TeamName: "team1"}},
+		unavailable: map[string]bool{"u1": true},
+	}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "author", IsActive: true},
+			{UserId: "u1", IsActive: true},
+			{UserId: "u2", IsActive: true},
+			{UserId: "u3", IsActive: true},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1"}},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, &fakePRRepo{}, trepo, urepo, nil)
+
+	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+	if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if slices.Contains(pr.AssignedReviewers, "u1") || len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("expected u2 and u3, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestReassignOpenReviews(t *testing.T) {
+	urepo := &fakeUserRepo{
+		users: map[string]api.User{
+			"author": {UserId: "author", TeamName: "backend"},
+			"b1":     {UserId: "b1", TeamName: "backend"},
+		},
+		unavailable: map[string]bool{"b1": true},
+	}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+		},
+		teams: map[string]api.Team{"backend": {TeamName: "backend"}},
+	}
+	open := api.PullRequest{PullRequestId: "pr1", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}}
+	full := api.PullRequest{PullRequestId: "pr2", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1", "b2"}}
+	merged := api.PullRequest{PullRequestId: "pr3", AuthorId: "author", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"b1"}}
+	prrepo := &fakePRRepo{
+		prs:           map[string]api.PullRequest{"pr1": open, "pr2": full, "pr3": merged},
+		prsByReviewer: map[string][]api.PullRequest{"b1": {open, full, merged}},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	results, err := svc.ReassignOpenReviews("b1")
+	if err != nil {
+		t.Fatalf("ReassignOpenReviews failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected only open PRs, got %+v", results)
+	}
+	if results[0].ReplacedBy == nil || *results[0].ReplacedBy != "b2" {
+		t.Fatalf("expected pr1 to move to b2, got %+v", results[0])
+	}
+	if results[1].Error != ErrNoReplacementCandidateInTeam.Error() {
+		t.Fatalf("expected pr2 to report no candidate, got %+v", results[1])
+	}
+}
+
+func TestHandOverReviews_Table(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		name       string
+		start, end string
+		wantMoved  bool
+	}{
+		{"under way", "2025-03-08", "2025-03-12", true},
+		{"starts soon", "2025-03-12", "2025-03-20", true},
+		{"starts later", "2025-03-13", "2025-03-20", false},
+		{"already over", "2025-03-01", "2025-03-09", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{
+				"author": {UserId: "author", TeamName: "backend"},
+				"b1":     {UserId: "b1", TeamName: "backend"},
+			}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{
+					"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+				},
+				teams: map[string]api.Team{"backend": {TeamName: "backend"}},
+			}
+			open := api.PullRequest{PullRequestId: "pr1", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}}
+			prrepo := &fakePRRepo{
+				prs:           map[string]api.PullRequest{"pr1": open},
+				prsByReviewer: map[string][]api.PullRequest{"b1": {open}},
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil, WithClock(clock))
+
+			results, err := svc.HandOverReviews(api.Unavailability{UserId: "b1", StartDate: tc.start, EndDate: tc.end})
+			if err != nil {
+				t.Fatalf("HandOverReviews failed: %v", err)
+			}
+			if moved := len(results) == 1 && results[0].ReplacedBy != nil; moved != tc.wantMoved {
+				t.Fatalf("want moved %v got %+v", tc.wantMoved, results)
+			}
+			if !tc.wantMoved && (results != nil || len(prrepo.updated) != 0) {
+				t.Fatalf("expected reviews to stay, got %+v", results)
+			}
+		})
+	}
+}
+
+func TestDeactivateUser_Table(t *testing.T) {
+	cases := []struct {
+		name         string
+		userID       string
+		reassign     bool
+		reviewerErr  error
+		wantErr      error
+		wantMoved    bool
+		wantReassign string
+	}{
+		{"unknown user", "ghost", true, nil, ErrUserNotFound, false, ""},
+		{"deactivate only", "b1", false, nil, nil, false, ""},
+		{"deactivate and reassign", "b1", true, nil, nil, true, ""},
+		{"listing failure keeps deactivation", "b1", true, repositoryError("db down"), nil, false, "open reviews could not be listed"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{
+				"author": {UserId: "author", TeamName: "backend", IsActive: true},
+				"b1":     {UserId: "b1", TeamName: "backend", IsActive: true},
+			}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{
+					"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+				},
+				teams: map[string]api.Team{"backend": {TeamName: "backend"}},
+			}
+			open := api.PullRequest{PullRequestId: "pr1", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}}
+			prrepo := &fakePRRepo{
+				prs:           map[string]api.PullRequest{"pr1": open},
+				prsByReviewer: map[string][]api.PullRequest{"b1": {open}},
+				reviewerErr:   tc.reviewerErr,
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			result, err := svc.DeactivateUser(tc.userID, tc.reassign)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v got %v", tc.wantErr, err)
+				}
+				if len(urepo.deactivated) != 0 {
+					t.Fatalf("expected no status change, got %v", urepo.deactivated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeactivateUser failed: %v", err)
+			}
+			if result.User.IsActive || len(urepo.deactivated) != 1 {
+				t.Fatalf("expected %s to be deactivated, got %+v", tc.userID, result.User)
+			}
+			if moved := len(result.Reassigned) == 1 && result.Reassigned[0].ReplacedBy != nil; moved != tc.wantMoved {
+				t.Fatalf("want moved %v got %+v", tc.wantMoved, result.Reassigned)
+			}
+			if result.ReassignError != tc.wantReassign {
+				t.Fatalf("want reassign error %q got %q", tc.wantReassign, result.ReassignError)
+			}
+		})
+	}
+}
+
+func TestAtWork_Table(t *testing.T) {
+	berlin := api.WorkingHours{Timezone: "Europe/Berlin", Start: "09:00", End: "17:00"}
+	night := api.WorkingHours{Timezone: "UTC", Start: "22:00", End: "06:00"}
+
+	cases := []struct {
+		name      string
+		hours     api.WorkingHours
+		at        time.Time
+		lookahead time.Duration
+		want      bool
+	}{
+		{"inside window", berlin, time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), 0, true},
+		{"after hours", berlin, time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC), 0, false},
+		{"end is exclusive", berlin, time.Date(2025, 1, 15, 16, 0, 0, 0, time.UTC), 0, false},
+		{"starts within lookahead", berlin, time.Date(2025, 1, 15, 5, 30, 0, 0, time.UTC), 3 * time.Hour, true},
+		{"starts after lookahead", berlin, time.Date(2025, 1, 15, 4, 0, 0, 0, time.UTC), 3 * time.Hour, false},
+		{"night shift after midnight", night, time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC), 0, true},
+		{"night shift daytime", night, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), 0, false},
+		{"invalid timezone", api.WorkingHours{Timezone: "Nowhere", Start: "09:00", End: "17:00"}, time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC), 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := atWork(tc.hours, tc.at, tc.lookahead); got != tc.want {
+				t.Fatalf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreatePR_PrefersReviewersAtWork(t *testing.T) {
+	// 18:00 in Berlin: Europe has left, New York and Tokyo differ in how soon they start.
+	clock := func() time.Time { return time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC) }
+	hours := map[string]api.WorkingHours{
+		"berlin":   {Timezone: "Europe/Berlin", Start: "09:00", End: "17:00"},
+		"new_york": {Timezone: "America/New_York", Start: "09:00", End: "17:00"},
+		"tokyo":    {Timezone: "Asia/Tokyo", Start: "09:00", End: "18:00"},
+	}
+
+	cases := []struct {
+		name      string
+		mode      api.TeamWorkingHoursMode
+		lookahead int
+		want      []string
+	}{
+		{"at work now", api.TeamWorkingHoursModePrefer, 0, []string{"new_york"}},
+		{"within lookahead", api.TeamWorkingHoursModePrefer, 7, []string{"new_york", "tokyo"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}, workingHours: hours}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"team1": {
+					{UserId: "author", IsActive: true},
+					{UserId: "berlin", IsActive: true},
+					{UserId: "new_york", IsActive: true},
+					{UserId: "tokyo", IsActive: true},
+				}},
+				teams: map[string]api.Team{"team1": {TeamName: "team1", RequiredReviewers: len(tc.want), WorkingHoursMode: tc.mode, WorkingHoursLookahead: tc.lookahead}},
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, &fakePRRepo{}, trepo, urepo, nil, WithClock(clock))
+
+			pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+			if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+				t.Fatalf("CreatePR failed: %v", err)
+			}
+			if !pr.CreatedAt.Equal(clock()) {
+				t.Fatalf("expected CreatedAt from injected clock, got %v", pr.CreatedAt)
+			}
+			got := slices.Clone(pr.AssignedReviewers)
+			slices.Sort(got)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want reviewers %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreatePR_RequiresSeniorReviewer(t *testing.T) {
+	members := []api.TeamMember{
+		{UserId: "author", IsActive: true},
+		{UserId: "j1", IsActive: true, Seniority: api.SeniorityJunior},
+		{UserId: "j2", IsActive: true, Seniority: api.SeniorityJunior},
+		{UserId: "j3", IsActive: true},
+		{UserId: "lead", IsActive: true, Seniority: api.SeniorityLead},
+	}
+
+	cases := []struct {
+		name    string
+		members []api.TeamMember
+		wantErr error
+	}{
+		{"senior picked first", members, nil},
+		{"no senior available", members[:4], ErrNoSeniorReviewer},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"team1": tc.members},
+				teams:   map[string]api.Team{"team1": {TeamName: "team1", AssignmentStrategy: api.TeamAssignmentStrategyRandom, RequireSenior: true}},
+			}
+			prrepo := &fakePRRepo{}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+			_, err := svc.CreatePR(pr, api.CreatePROptions{})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) || len(prrepo.created) != 0 {
+					t.Fatalf("want %v and no PR, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreatePR failed: %v", err)
+			}
+			if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "lead" {
+				t.Fatalf("expected lead plus one more reviewer, got %v", pr.AssignedReviewers)
+			}
+		})
+	}
+}
+
+func TestReassignReviewer_KeepsSeniorReviewer(t *testing.T) {
+	cases := []struct {
+		name    string
+		members []api.TeamMember
+		want    string
+		wantErr error
+	}{
+		{
+			"senior replaced by senior",
+			[]api.TeamMember{
+				{UserId: "s1", IsActive: true, Seniority: api.SenioritySenior},
+				{UserId: "j2", IsActive: true, Seniority: api.SeniorityJunior},
+				{UserId: "j3", IsActive: true, Seniority: api.SeniorityJunior},
+				{UserId: "s2", IsActive: true, Seniority: api.SenioritySenior},
+			},
+			"s2", nil,
+		},
+		{
+			"no senior left",
+			[]api.TeamMember{
+				{UserId: "s1", IsActive: true, Seniority: api.SenioritySenior},
+				{UserId: "j2", IsActive: true, Seniority: api.SeniorityJunior},
+				{UserId: "j3", IsActive: true, Seniority: api.SeniorityJunior},
+			},
+			"", ErrNoSeniorReviewer,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{
+				"author": {UserId: "author", TeamName: "backend"},
+				"s1":     {UserId: "s1", TeamName: "backend", Seniority: api.SenioritySenior},
+				"j2":     {UserId: "j2", TeamName: "backend", Seniority: api.SeniorityJunior},
+			}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"backend": tc.members},
+				teams:   map[string]api.Team{"backend": {TeamName: "backend", RequireSenior: true}},
+			}
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+				PullRequestId:     "pr1",
+				AuthorId:          "author",
+				Status:            api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{"s1", "j2"},
+			}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			_, newReviewer, _, err := svc.ReassignReviewer("pr1", "s1", api.ReassignOptions{})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) || len(prrepo.updated) != 0 {
+					t.Fatalf("want %v and no update, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReassignReviewer failed: %v", err)
+			}
+			if *newReviewer != tc.want {
+				t.Fatalf("want replacement %s got %s", tc.want, *newReviewer)
+			}
+		})
+	}
+}
+
+func TestCreatePR_SeedReplaysAssignment(t *testing.T) {
+	newService := func(opts ...Option) *Service {
+		urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+		members := []api.TeamMember{{UserId: "author", IsActive: true}}
+		for _, id := range []string{"u1", "u2", "u3", "u4", "u5", "u6"} {
+			members = append(members, api.TeamMember{UserId: id, IsActive: true})
+		}
+		trepo := &fakeTeamRepo{
+			members: map[string][]api.TeamMember{"team1": members},
+			teams:   map[string]api.Team{"team1": {TeamName: "team1", AssignmentStrategy: api.TeamAssignmentStrategyRandom}},
+		}
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		return NewService(logger, &fakePRRepo{}, trepo, urepo, nil, opts...)
+	}
+	create := func(svc *Service, opts api.CreatePROptions) *api.PullRequest {
+		pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+		if _, err := svc.CreatePR(pr, opts); err != nil {
+			t.Fatalf("CreatePR failed: %v", err)
+		}
+		return pr
+	}
+
+	t.Run("seed recorded and replayed", func(t *testing.T) {
+		first := create(newService(), api.CreatePROptions{})
+		seed := first.Reviewers[0].AssignmentSeed
+		for _, r := range first.Reviewers {
+			if r.AssignmentSeed == nil || *r.AssignmentSeed != *seed {
+				t.Fatalf("expected every reviewer to carry the assignment seed, got %+v", first.Reviewers)
+			}
+		}
+		for i := 0; i < 5; i++ {
+			replay := create(newService(), api.CreatePROptions{Seed: seed})
+			if !slices.Equal(replay.AssignedReviewers, first.AssignedReviewers) {
+				t.Fatalf("replay with seed %d: want %v got %v", *seed, first.AssignedReviewers, replay.AssignedReviewers)
+			}
+		}
+	})
+
+	t.Run("seeded source is reproducible", func(t *testing.T) {
+		a := create(newService(WithSeedSource(NewSeedSource(42))), api.CreatePROptions{})
+		b := create(newService(WithSeedSource(NewSeedSource(42))), api.CreatePROptions{})
+		if !slices.Equal(a.AssignedReviewers, b.AssignedReviewers) || *a.Reviewers[0].AssignmentSeed != *b.Reviewers[0].AssignmentSeed {
+			t.Fatalf("expected identical assignments, got %+v and %+v", a.Reviewers, b.Reviewers)
+		}
+	})
+
+	t.Run("previews do not advance the seeded source", func(t *testing.T) {
+		svc := newService(WithSeedSource(NewSeedSource(42)))
+		for i := 0; i < 3; i++ {
+			if _, err := svc.PreviewPR(&api.PullRequest{AuthorId: "author"}, api.CreatePROptions{}); err != nil {
+				t.Fatalf("PreviewPR failed: %v", err)
+			}
+		}
+		a := create(svc, api.CreatePROptions{})
+		b := create(newService(WithSeedSource(NewSeedSource(42))), api.CreatePROptions{})
+		if *a.Reviewers[0].AssignmentSeed != *b.Reviewers[0].AssignmentSeed {
+			t.Fatalf("previews moved the seed sequence: %d != %d", *a.Reviewers[0].AssignmentSeed, *b.Reviewers[0].AssignmentSeed)
+		}
+	})
+}
+
+func TestCreatePR_AvoidsRecentPairings(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		name        string
+		pairings    map[string]int
+		openReviews map[string]int
+		want        []string
+	}{
+		{"frequent reviewers rank last", map[string]int{"u1": 3, "u2": 1}, nil, []string{"u2", "u3"}},
+		{"repeats weigh like open reviews", map[string]int{"u1": 1}, map[string]int{"u2": 2, "u3": 3}, []string{"u1", "u2"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+				trepo := &fakeTeamRepo{
+					members: map[string][]api.TeamMember{"team1": {
+						{UserId: "author", IsActive: true},
+						{UserId: "u1", IsActive: true},
+						{UserId: "u2", IsActive: true},
+						{UserId: "u3", IsActive: true},
+					}},
+					teams: map[string]api.Team{"team1": {
+						TeamName:            "team1",
+						AssignmentStrategy:  api.TeamAssignmentStrategyLeastLoaded,
+						PairingMode:         api.TeamPairingModeAvoidRecent,
+						PairingLookbackDays: 14,
+					}},
+				}
+				prrepo := &fakePRRepo{pairings: map[string]map[string]int{"author": tc.pairings}, openReviews: tc.openReviews}
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				svc := NewService(logger, prrepo, trepo, urepo, nil, WithClock(clock))
+
+				pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+				if _, err := svc.CreatePR(pr, api.CreatePROptions{}); err != nil {
+					t.Fatalf("CreatePR failed: %v", err)
+				}
+				got := slices.Clone(pr.AssignedReviewers)
+				slices.Sort(got)
+				if !slices.Equal(got, tc.want) {
+					t.Fatalf("want reviewers %v got %v", tc.want, got)
+				}
+				if want := clock().AddDate(0, 0, -14); !prrepo.pairingsSince.Equal(want) {
+					t.Fatalf("want lookback since %v got %v", want, prrepo.pairingsSince)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPairingMatrix(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC) }
+	seven, zero := 7, 0
+
+	cases := []struct {
+		name         string
+		team         string
+		lookbackDays *int
+		wantDays     int
+		wantErr      error
+	}{
+		{"team missing", "nope", nil, 0, ErrTeamNotFound},
+		{"invalid lookback", "team1", &zero, 0, ErrInvalidLookback},
+		{"team default", "team1", nil, service.DefaultPairingLookbackDays, nil},
+		{"explicit lookback", "team1", &seven, 7, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			trepo := &fakeTeamRepo{teams: map[string]api.Team{"team1": {TeamName: "team1"}}}
+			prrepo := &fakePRRepo{pairings: map[string]map[string]int{"author": {"u1": 2}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, &fakeUserRepo{}, nil, WithClock(clock))
+
+			matrix, err := svc.GetPairingMatrix(tc.team, tc.lookbackDays)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if matrix.LookbackDays != tc.wantDays || !matrix.Since.Equal(clock().AddDate(0, 0, -tc.wantDays)) {
+				t.Fatalf("want %d day window, got %d since %v", tc.wantDays, matrix.LookbackDays, matrix.Since)
+			}
+			if matrix.Matrix["author"]["u1"] != 2 {
+				t.Fatalf("unexpected matrix %v", matrix.Matrix)
+			}
+		})
+	}
+}
+
+func TestCreatePR_TracesAssignment(t *testing.T) {
+	one := 1
+	urepo := &fakeUserRepo{
+		users:       map[string]api.User{"author": {UserId: "author", TeamName: "team1"}},
+		unavailable: map[string]bool{"away": true},
+	}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "author", IsActive: true},
+			{UserId: "away", IsActive: true},
+			{UserId: "busy", IsActive: true, MaxOpenReviews: &one},
+			{UserId: "gone", IsActive: false},
+			{UserId: "u1", IsActive: true},
+			{UserId: "u2", IsActive: true},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1", AssignmentStrategy: api.TeamAssignmentStrategyRandom}},
+	}
+	prrepo := &fakePRRepo{openReviews: map[string]int{"busy": 1}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr := &api.PullRequest{PullRequestId: "pr1", PullRequestName: "PR 1", AuthorId: "author"}
+	trace, err := svc.CreatePR(pr, api.CreatePROptions{})
+	if err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if len(prrepo.traces) != 1 || prrepo.traces[0].PullRequestId != "pr1" {
+		t.Fatalf("expected the trace to be stored, got %+v", prrepo.traces)
+	}
+	if trace.Action != api.AssignmentTraceActionCreate || trace.AssignmentSeed != *pr.Reviewers[0].AssignmentSeed {
+		t.Fatalf("unexpected trace header %+v", trace)
+	}
+	if len(trace.Steps) != 1 || trace.Steps[0].Stage != api.AssignmentTraceStageFill {
+		t.Fatalf("expected one fill step, got %+v", trace.Steps)
+	}
+
+	want := map[string]api.CandidateExclusion{
+		"author": api.CandidateExclusionAuthor,
+		"away":   api.CandidateExclusionUnavailable,
+		"busy":   api.CandidateExclusionAtCapacity,
+		"gone":   api.CandidateExclusionInactive,
+		"u1":     "",
+		"u2":     "",
+	}
+	step := trace.Steps[0]
+	if len(step.Candidates) != len(want) {
+		t.Fatalf("want %d candidates got %+v", len(want), step.Candidates)
+	}
+	for _, c := range step.Candidates {
+		if c.Excluded != want[c.UserId] {
+			t.Fatalf("candidate %s: want exclusion %q got %q", c.UserId, want[c.UserId], c.Excluded)
+		}
+	}
+	if !slices.Equal(step.Picked, pr.AssignedReviewers) || !slices.Equal(trace.Reviewers, pr.AssignedReviewers) {
+		t.Fatalf("trace picks %v do not match reviewers %v", step.Picked, pr.AssignedReviewers)
+	}
+}
+
+func TestReassignReviewer_TracesReplacement(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend"},
+		"b1":     {UserId: "b1", TeamName: "backend"},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+		},
+		teams: map[string]api.Team{"backend": {TeamName: "backend"}},
+	}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+		PullRequestId:     "pr1",
+		AuthorId:          "author",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"b1"},
+	}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	_, _, trace, err := svc.ReassignReviewer("pr1", "b1", api.ReassignOptions{})
+	if err != nil {
+		t.Fatalf("ReassignReviewer failed: %v", err)
+	}
+	if trace.Action != api.AssignmentTraceActionReassign || trace.ReplacedUserId != "b1" || !slices.Equal(trace.Reviewers, []string{"b2"}) {
+		t.Fatalf("unexpected trace %+v", trace)
+	}
+	step := trace.Steps[0]
+	if step.Stage != api.AssignmentTraceStageReplacement || !slices.Equal(step.Picked, []string{"b2"}) {
+		t.Fatalf("unexpected step %+v", step)
+	}
+	if c := traceCandidate(&step, "b1"); c == nil || c.Excluded != api.CandidateExclusionAlreadyAssigned {
+		t.Fatalf("expected b1 to be excluded as already assigned, got %+v", step.Candidates)
+	}
+	if len(prrepo.traces) != 1 {
+		t.Fatalf("expected the trace to be stored, got %d", len(prrepo.traces))
+	}
+}
+
+func TestDeactivateUsersAndReassignPRs_TracesBackfill(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "frontend", IsActive: true},
+		"b1":     {UserId: "b1", TeamName: "backend", IsActive: true},
+		"b2":     {UserId: "b2", TeamName: "backend", IsActive: true},
+		"b3":     {UserId: "b3", TeamName: "backend", IsActive: true},
+	}}
+	trepo := &fakeTeamRepo{teams: map[string]api.Team{"backend": {TeamName: "backend", RequiredReviewers: 2}}}
+	pr := api.PullRequest{PullRequestId: "pr1", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1", "b2"}}
+	prrepo := &fakePRRepo{prsByReviewer: map[string][]api.PullRequest{"b1": {pr}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	if _, err := svc.DeactivateUsersAndReassignPRs("backend", []string{"b1"}); err != nil {
+		t.Fatalf("DeactivateUsersAndReassignPRs failed: %v", err)
+	}
+	if len(prrepo.traces) != 1 {
+		t.Fatalf("expected one stored trace, got %d", len(prrepo.traces))
+	}
+	trace := prrepo.traces[0]
+	if trace.Action != api.AssignmentTraceActionReassign || trace.ReplacedUserId != "b1" || !slices.Equal(trace.Reviewers, []string{"b2", "b3"}) {
+		t.Fatalf("unexpected trace %+v", trace)
+	}
+	step := trace.Steps[0]
+	if step.Stage != api.AssignmentTraceStageFill || !slices.Equal(step.Picked, []string{"b3"}) {
+		t.Fatalf("unexpected step %+v", step)
+	}
+	if c := traceCandidate(&step, "b2"); c == nil || c.Excluded != api.CandidateExclusionAlreadyAssigned {
+		t.Fatalf("expected b2 to be excluded as already assigned, got %+v", step.Candidates)
+	}
+}
+
+func TestDeactivateUsersAndReassignPRs_AuthorTeamRules(t *testing.T) {
+	cases := []struct {
+		name      string
+		authorID  string
+		frontend  api.Team
+		want      []string
+		wantError string
+	}{
+		{"author team needs fewer seats", "author", api.Team{TeamName: "frontend", RequiredReviewers: 1}, []string{"b2"}, ""},
+		{"author team needs more seats", "author", api.Team{TeamName: "frontend", RequiredReviewers: 3}, []string{"b2", "b3", "s1"}, ""},
+		{"author team requires a senior", "author", api.Team{TeamName: "frontend", RequiredReviewers: 2, RequireSenior: true}, []string{"b2", "s1"}, ""},
+		{"unknown author", "ghost", api.Team{TeamName: "frontend"}, nil, "author not found: pr1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{
+				"author": {UserId: "author", TeamName: "frontend", IsActive: true},
+				"b1":     {UserId: "b1", TeamName: "backend", IsActive: true},
+				"b2":     {UserId: "b2", TeamName: "backend", IsActive: true},
+				"b3":     {UserId: "b3", TeamName: "backend", IsActive: true},
+				"s1":     {UserId: "s1", TeamName: "backend", IsActive: true, Seniority: api.SenioritySenior},
+			}}
+			trepo := &fakeTeamRepo{teams: map[string]api.Team{
+				"backend":  {TeamName: "backend", RequiredReviewers: 2},
+				"frontend": tc.frontend,
+			}}
+			pr := api.PullRequest{PullRequestId: "pr1", AuthorId: tc.authorID, Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1", "b2"}}
+			prrepo := &fakePRRepo{prsByReviewer: map[string][]api.PullRequest{"b1": {pr}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			resp, err := svc.DeactivateUsersAndReassignPRs("backend", []string{"b1"})
+			if err != nil {
+				t.Fatalf("DeactivateUsersAndReassignPRs failed: %v", err)
+			}
+			if tc.wantError != "" {
+				if len(resp.Errors) != 1 || resp.Errors[0].Error != tc.wantError || len(prrepo.updated) != 0 {
+					t.Fatalf("want error %q and no update, got %+v", tc.wantError, resp.Errors)
+				}
+				return
+			}
+			if len(prrepo.updated) != 1 {
+				t.Fatalf("expected one updated PR, got %+v", prrepo.updated)
+			}
+			got := slices.Sorted(slices.Values(prrepo.updated[0].AssignedReviewers))
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want reviewers %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPreviewPR_StoresNothing(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "author", IsActive: true},
+			{UserId: "u1", IsActive: true},
+			{UserId: "u2", IsActive: true},
+			{UserId: "u3", IsActive: false},
+			{UserId: "u4", IsActive: true},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1", AssignmentStrategy: api.TeamAssignmentStrategyRoundRobin}},
+	}
+	prrepo := &fakePRRepo{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	preview := func() *api.AssignmentPreview {
+		p, err := svc.PreviewPR(&api.PullRequest{AuthorId: "author"}, api.CreatePROptions{})
+		if err != nil {
+			t.Fatalf("PreviewPR failed: %v", err)
+		}
+		return p
+	}
+
+	first := preview()
+	if got := memberIDs(first.Eligible); !slices.Equal(got, []string{"u1", "u2", "u4"}) {
+		t.Fatalf("expected eligible pool [u1 u2 u4], got %v", got)
+	}
+	if len(first.Reviewers) != 2 || first.Trace == nil || !first.Trace.DryRun {
+		t.Fatalf("unexpected preview %+v", first)
+	}
+	if again := preview(); !slices.Equal(reviewerIDs(again.Reviewers), reviewerIDs(first.Reviewers)) {
+		t.Fatalf("preview advanced the rotation: %v then %v", reviewerIDs(first.Reviewers), reviewerIDs(again.Reviewers))
+	}
+	if len(prrepo.created) != 0 || len(prrepo.traces) != 0 {
+		t.Fatalf("preview stored %d PRs and %d traces", len(prrepo.created), len(prrepo.traces))
+	}
+
+	pr := &api.PullRequest{PullRequestId: "pr1", AuthorId: "author"}
+	if _, err := svc.CreatePR(pr, api.CreatePROptions{Seed: &first.AssignmentSeed}); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if !slices.Equal(pr.AssignedReviewers, reviewerIDs(first.Reviewers)) {
+		t.Fatalf("expected CreatePR to pick the previewed %v, got %v", reviewerIDs(first.Reviewers), pr.AssignedReviewers)
+	}
+}
+
+func TestReassignReviewer_DryRun(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend"},
+		"b1":     {UserId: "b1", TeamName: "backend"},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}},
+		},
+		teams: map[string]api.Team{"backend": {TeamName: "backend"}},
+	}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+		PullRequestId:     "pr1",
+		AuthorId:          "author",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"b1"},
+	}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr, newReviewer, trace, err := svc.ReassignReviewer("pr1", "b1", api.ReassignOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ReassignReviewer failed: %v", err)
+	}
+	if *newReviewer != "b2" || !slices.Equal(pr.AssignedReviewers, []string{"b2"}) || !trace.DryRun {
+		t.Fatalf("unexpected dry run result %v %+v %+v", *newReviewer, pr, trace)
+	}
+	if len(prrepo.updated) != 0 || len(prrepo.traces) != 0 {
+		t.Fatalf("dry run stored %d updates and %d traces", len(prrepo.updated), len(prrepo.traces))
+	}
+}
+
+func TestCreatePR_RequestedReviewers(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend", IsActive: true},
+		"b1":     {UserId: "b1", TeamName: "backend", IsActive: true},
+		"b2":     {UserId: "b2", TeamName: "backend", IsActive: true},
+		"idle":   {UserId: "idle", TeamName: "backend", IsActive: false},
+		"p1":     {UserId: "p1", TeamName: "platform", IsActive: true},
+		"m1":     {UserId: "m1", TeamName: "mobile", IsActive: true},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend":  {{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true}, {UserId: "idle", IsActive: false}},
+			"platform": {{UserId: "p1", IsActive: true}},
+			"mobile":   {{UserId: "m1", IsActive: true}},
+		},
+		teams: map[string]api.Team{
+			"backend":  {TeamName: "backend", FallbackTeams: []string{"platform"}},
+			"platform": {TeamName: "platform"},
+			"mobile":   {TeamName: "mobile"},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		requested []string
+		wantErr   error
+		want      []api.ReviewerSource
+	}{
+		{name: "one requested, one auto", requested: []string{"b1"}, want: []api.ReviewerSource{api.ReviewerSourceRequested, api.ReviewerSourceAuto}},
+		{name: "fallback team allowed", requested: []string{"p1", "p1"}, want: []api.ReviewerSource{api.ReviewerSourceRequested, api.ReviewerSourceAuto}},
+		{name: "all seats requested", requested: []string{"b1", "p1"}, want: []api.ReviewerSource{api.ReviewerSourceRequested, api.ReviewerSourceRequested}},
+		{name: "author", requested: []string{"author"}, wantErr: ErrRequestedReviewerIsAuthor},
+		{name: "inactive", requested: []string{"idle"}, wantErr: ErrRequestedReviewerInactive},
+		{name: "outside allowed teams", requested: []string{"m1"}, wantErr: ErrRequestedReviewerNotAllowed},
+		{name: "unknown", requested: []string{"ghost"}, wantErr: ErrRequestedReviewerNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			pr := &api.PullRequest{PullRequestId: "pr1", AuthorId: "author"}
+			trace, err := svc.CreatePR(pr, api.CreatePROptions{RequestedReviewers: tc.requested})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if len(prrepo.created) != 0 {
+					t.Fatalf("expected no PR to be stored")
+				}
+				return
+			}
+
+			var sources []api.ReviewerSource
+			for _, r := range pr.Reviewers {
+				sources = append(sources, r.Source)
+			}
+			if !slices.Equal(sources, tc.want) || pr.AssignedReviewers[0] != tc.requested[0] {
+				t.Fatalf("want sources %v with %s first, got %+v", tc.want, tc.requested[0], pr.Reviewers)
+			}
+			if trace.Steps[0].Stage != api.AssignmentTraceStageRequested {
+				t.Fatalf("expected the requested step first, got %+v", trace.Steps)
+			}
+		})
+	}
+}
+
+func TestAddReviewer(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend", IsActive: true},
+		"b1":     {UserId: "b1", TeamName: "backend", IsActive: true},
+		"idle":   {UserId: "idle", TeamName: "backend", IsActive: false},
+		"m1":     {UserId: "m1", TeamName: "mobile", IsActive: true},
+	}}
+
+	cases := []struct {
+		name     string
+		prID     string
+		userID   string
+		writeErr error
+		wantErr  error
+	}{
+		{name: "other team allowed", prID: "open", userID: "m1"},
+		{name: "already assigned", prID: "open", userID: "b1", wantErr: ErrReviewerAlreadyAssigned},
+		{name: "author", prID: "open", userID: "author", wantErr: ErrRequestedReviewerIsAuthor},
+		{name: "inactive", prID: "open", userID: "idle", wantErr: ErrRequestedReviewerInactive},
+		{name: "unknown user", prID: "open", userID: "ghost", wantErr: ErrRequestedReviewerNotFound},
+		{name: "PR changed meanwhile", prID: "open", userID: "m1", writeErr: repository.ErrPRChanged, wantErr: ErrPRChanged},
+		{name: "merged PR", prID: "merged", userID: "m1", wantErr: ErrCannotChangeMergedPR},
+		{name: "unknown PR", prID: "missing", userID: "m1", wantErr: ErrPRNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{
+				"open":   {PullRequestId: "open", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}},
+				"merged": {PullRequestId: "merged", AuthorId: "author", Status: api.PullRequestStatusMERGED},
+			}, writeErr: tc.writeErr}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, &fakeTeamRepo{}, urepo, nil)
+
+			pr, err := svc.AddReviewer(tc.prID, tc.userID)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if len(prrepo.updated) != 0 {
+					t.Fatalf("expected no update, got %v", prrepo.updated)
+				}
+				return
+			}
+			if !slices.Equal(pr.AssignedReviewers, []string{"b1", tc.userID}) || len(prrepo.updated) != 1 {
+				t.Fatalf("unexpected reviewers %v after %d updates", pr.AssignedReviewers, len(prrepo.updated))
+			}
+			if added := pr.Reviewers[len(pr.Reviewers)-1]; added.Source != api.ReviewerSourceRequested || added.TeamName != "mobile" {
+				t.Fatalf("unexpected added reviewer %+v", added)
+			}
+			if len(prrepo.traces) != 1 {
+				t.Fatalf("expected one stored trace, got %+v", prrepo.traces)
+			}
+			trace := prrepo.traces[0]
+			if trace.Action != api.AssignmentTraceActionAddReviewer || !slices.Equal(trace.Reviewers, pr.AssignedReviewers) ||
+				len(trace.Steps) != 1 || !slices.Equal(trace.Steps[0].Picked, []string{tc.userID}) {
+				t.Fatalf("unexpected trace %+v", trace)
+			}
+		})
+	}
+}
+
+func TestRemoveReviewer(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "frontend"},
+		"b1":     {UserId: "b1", TeamName: "backend", Seniority: api.SenioritySenior},
+		"b2":     {UserId: "b2", TeamName: "backend", Seniority: api.SeniorityJunior},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"backend": {
+			{UserId: "b1", IsActive: true, Seniority: api.SenioritySenior},
+			{UserId: "b2", IsActive: true, Seniority: api.SeniorityJunior},
+		}},
+		teams: map[string]api.Team{"frontend": {TeamName: "frontend"}},
+	}
+	seniorTeams := &fakeTeamRepo{members: trepo.members, teams: map[string]api.Team{"frontend": {TeamName: "frontend", RequireSenior: true}}}
+
+	cases := []struct {
+		name     string
+		prID     string
+		userID   string
+		teams    *fakeTeamRepo
+		writeErr error
+		wantErr  error
+	}{
+		{name: "assigned", prID: "open", userID: "b1"},
+		{name: "junior with required senior", prID: "open", userID: "b2", teams: seniorTeams},
+		{name: "only senior", prID: "open", userID: "b1", teams: seniorTeams, wantErr: ErrNoSeniorReviewer},
+		{name: "last reviewer", prID: "single", userID: "b1", wantErr: ErrLastReviewer},
+		{name: "not assigned", prID: "open", userID: "b3", wantErr: ErrReviewerNotAssigned},
+		{name: "PR changed meanwhile", prID: "open", userID: "b1", writeErr: repository.ErrPRChanged, wantErr: ErrPRChanged},
+		{name: "merged PR", prID: "merged", userID: "b1", wantErr: ErrCannotChangeMergedPR},
+		{name: "unknown PR", prID: "missing", userID: "b1", wantErr: ErrPRNotFound},
+		{name: "senior on a PR of an unknown author", prID: "orphan", userID: "b1", wantErr: ErrAuthorNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{
+				"open":   {PullRequestId: "open", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1", "b2"}},
+				"orphan": {PullRequestId: "orphan", AuthorId: "ghost", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1", "b2"}},
+				"single": {PullRequestId: "single", AuthorId: "author", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}},
+				"merged": {PullRequestId: "merged", AuthorId: "author", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"b1"}},
+			}, writeErr: tc.writeErr}
+			teams := tc.teams
+			if teams == nil {
+				teams = trepo
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, teams, urepo, nil)
+
+			pr, err := svc.RemoveReviewer(tc.prID, tc.userID)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if len(prrepo.updated) != 0 || len(prrepo.traces) != 0 {
+					t.Fatalf("expected no update, got %v and traces %+v", prrepo.updated, prrepo.traces)
+				}
+				return
+			}
+			want := slices.DeleteFunc([]string{"b1", "b2"}, func(id string) bool { return id == tc.userID })
+			if !slices.Equal(pr.AssignedReviewers, want) || len(prrepo.updated) != 1 {
+				t.Fatalf("unexpected reviewers %v after %d updates", pr.AssignedReviewers, len(prrepo.updated))
+			}
+			if len(prrepo.traces) != 1 {
+				t.Fatalf("expected one stored trace, got %+v", prrepo.traces)
+			}
+			if trace := prrepo.traces[0]; trace.Action != api.AssignmentTraceActionRemoveReviewer || trace.ReplacedUserId != tc.userID || !slices.Equal(trace.Reviewers, want) {
+				t.Fatalf("unexpected trace %+v", trace)
+			}
+		})
+	}
+}
+
+func TestReassignReviewer_TargetAndExclusions(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "backend", IsActive: true},
+		"b1":     {UserId: "b1", TeamName: "backend", IsActive: true},
+		"b2":     {UserId: "b2", TeamName: "backend", IsActive: true},
+		"b3":     {UserId: "b3", TeamName: "backend", IsActive: true},
+		"idle":   {UserId: "idle", TeamName: "backend", IsActive: false},
+		"p1":     {UserId: "p1", TeamName: "platform", IsActive: true},
+		"m1":     {UserId: "m1", TeamName: "mobile", IsActive: true},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{
+			"backend": {
+				{UserId: "author", IsActive: true}, {UserId: "b1", IsActive: true}, {UserId: "b2", IsActive: true},
+				{UserId: "b3", IsActive: true}, {UserId: "idle", IsActive: false},
+			},
+			"platform": {{UserId: "p1", IsActive: true}},
+			"mobile":   {{UserId: "m1", IsActive: true}},
+		},
+		teams: map[string]api.Team{
+			"backend":  {TeamName: "backend", FallbackTeams: []string{"platform"}},
+			"platform": {TeamName: "platform"},
+			"mobile":   {TeamName: "mobile"},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		opts    api.ReassignOptions
+		want    string
+		wantErr error
+	}{
+		{name: "target in team", opts: api.ReassignOptions{NewUserID: "b3"}, want: "b3"},
+		{name: "target in fallback team", opts: api.ReassignOptions{NewUserID: "p1"}, want: "p1"},
+		{name: "target outside teams", opts: api.ReassignOptions{NewUserID: "m1"}, wantErr: ErrReplacementNotAllowed},
+		{name: "inactive target", opts: api.ReassignOptions{NewUserID: "idle"}, wantErr: ErrReplacementNotEligible},
+		{name: "author as target", opts: api.ReassignOptions{NewUserID: "author"}, wantErr: ErrReplacementNotEligible},
+		{name: "outgoing reviewer as target", opts: api.ReassignOptions{NewUserID: "b1"}, wantErr: ErrReviewerAlreadyAssigned},
+		{name: "unknown target", opts: api.ReassignOptions{NewUserID: "ghost"}, wantErr: ErrRequestedReviewerNotFound},
+		{name: "excluded target", opts: api.ReassignOptions{NewUserID: "b2", ExcludeUserIDs: []string{"b2"}}, wantErr: ErrReplacementNotEligible},
+		{name: "exclusions leave one in team", opts: api.ReassignOptions{ExcludeUserIDs: []string{"b2"}}, want: "b3"},
+		{name: "exclusions move to fallback team", opts: api.ReassignOptions{ExcludeUserIDs: []string{"b2", "b3"}}, want: "p1"},
+		{name: "everyone excluded", opts: api.ReassignOptions{ExcludeUserIDs: []string{"b2", "b3", "p1"}}, wantErr: ErrNoReplacementCandidateInTeam},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+				PullRequestId:     "pr1",
+				AuthorId:          "author",
+				Status:            api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{"b1"},
+			}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			_, newReviewer, _, err := svc.ReassignReviewer("pr1", "b1", tc.opts)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr == nil && *newReviewer != tc.want {
+				t.Fatalf("want replacement %s got %s", tc.want, *newReviewer)
+			}
+		})
+	}
+}
+
+func TestRebalanceTeam(t *testing.T) {
+	newRepo := func() *fakePRRepo {
+		var reviews []api.PullRequest
+		for i, author := range []string{"b2", "x1", "x2", "x3"} {
+			reviews = append(reviews, api.PullRequest{
+				PullRequestId:     fmt.Sprintf("pr%d", i+1),
+				AuthorId:          author,
+				Status:            api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{"b1"},
+				Reviewers:         []api.ReviewerAssignment{{UserId: "b1", TeamName: "backend", Source: api.ReviewerSourceRequested}},
+			})
+		}
+		return &fakePRRepo{
+			openByTeam:  map[string][]api.PullRequest{"backend": reviews},
+			openReviews: map[string]int{"b1": 4},
+		}
+	}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"backend": {
+			{UserId: "b1", IsActive: true},
+			{UserId: "b2", IsActive: true},
+			{UserId: "b3", IsActive: true},
+			{UserId: "idle", IsActive: false},
+		}},
+		teams: map[string]api.Team{"backend": {TeamName: "backend"}},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	wantMoves := []api.RebalanceMove{
+		{PullRequestId: "pr2", FromUserId: "b1", ToUserId: "b2"},
+		{PullRequestId: "pr1", FromUserId: "b1", ToUserId: "b3"},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		prrepo := newRepo()
+		plan, err := NewService(logger, prrepo, trepo, &fakeUserRepo{}, nil).RebalanceTeam("backend", false)
+		if err != nil {
+			t.Fatalf("RebalanceTeam failed: %v", err)
+		}
+		if !slices.Equal(plan.Moves, wantMoves) || plan.Applied || len(prrepo.moved) != 0 || len(prrepo.traces) != 0 {
+			t.Fatalf("unexpected plan %+v with %d moves applied", plan, len(prrepo.moved))
+		}
+		if want := map[string]int{"b1": 2, "b2": 1, "b3": 1}; !maps.Equal(plan.OpenReviewsAfter, want) {
+			t.Fatalf("want loads %v got %v", want, plan.OpenReviewsAfter)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		prrepo := newRepo()
+		plan, err := NewService(logger, prrepo, trepo, &fakeUserRepo{}, nil).RebalanceTeam("backend", true)
+		if err != nil {
+			t.Fatalf("RebalanceTeam failed: %v", err)
+		}
+		if !slices.Equal(plan.Moves, wantMoves) || !plan.Applied || !slices.Equal(prrepo.moved, wantMoves) {
+			t.Fatalf("unexpected plan %+v with moves applied %+v", plan, prrepo.moved)
+		}
+		if len(prrepo.traces) != len(wantMoves) {
+			t.Fatalf("expected a trace per move, got %+v", prrepo.traces)
+		}
+		for i, trace := range prrepo.traces {
+			move := wantMoves[i]
+			if trace.Action != api.AssignmentTraceActionRebalance || trace.PullRequestId != move.PullRequestId ||
+				trace.ReplacedUserId != move.FromUserId || !slices.Equal(trace.Reviewers, []string{move.ToUserId}) {
+				t.Fatalf("unexpected trace %+v for move %+v", trace, move)
+			}
+		}
+	})
+
+	t.Run("moved review keeps its source", func(t *testing.T) {
+		pr := newRepo().openByTeam["backend"][1]
+		moveReview(&pr, wantMoves[0], "backend")
+		want := []api.ReviewerAssignment{{UserId: "b2", TeamName: "backend", Source: api.ReviewerSourceRequested}}
+		if !slices.Equal(pr.AssignedReviewers, []string{"b2"}) || !slices.Equal(pr.Reviewers, want) {
+			t.Fatalf("unexpected reviewers %+v", pr.Reviewers)
+		}
+	})
+
+	t.Run("PR changed before apply", func(t *testing.T) {
+		prrepo := newRepo()
+		prrepo.writeErr = repository.ErrPRChanged
+		if _, err := NewService(logger, prrepo, trepo, &fakeUserRepo{}, nil).RebalanceTeam("backend", true); !errors.Is(err, ErrPRChanged) {
+			t.Fatalf("want ErrPRChanged got %v", err)
+		}
+	})
+
+	t.Run("keeps the only senior reviewer", func(t *testing.T) {
+		urepo := &fakeUserRepo{users: map[string]api.User{"x1": {UserId: "x1", TeamName: "frontend"}}}
+		seniorRepo := &fakeTeamRepo{
+			members: map[string][]api.TeamMember{"backend": {
+				{UserId: "b1", IsActive: true, Seniority: api.SenioritySenior},
+				{UserId: "b2", IsActive: true, Seniority: api.SeniorityJunior},
+			}},
+			teams: map[string]api.Team{
+				"backend":  {TeamName: "backend"},
+				"frontend": {TeamName: "frontend", RequireSenior: true},
+			},
+		}
+		prrepo := &fakePRRepo{
+			openByTeam: map[string][]api.PullRequest{"backend": {
+				{PullRequestId: "pr1", AuthorId: "x1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}},
+				{PullRequestId: "pr2", AuthorId: "x1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"b1"}},
+			}},
+			openReviews: map[string]int{"b1": 2},
+		}
+		plan, err := NewService(logger, prrepo, seniorRepo, urepo, nil).RebalanceTeam("backend", false)
+		if err != nil {
+			t.Fatalf("RebalanceTeam failed: %v", err)
+		}
+		if len(plan.Moves) != 0 {
+			t.Fatalf("expected no moves, got %+v", plan.Moves)
+		}
+
+		if _, err := NewService(logger, prrepo, seniorRepo, &fakeUserRepo{}, nil).RebalanceTeam("backend", false); !errors.Is(err, ErrAuthorNotFound) {
+			t.Fatalf("want ErrAuthorNotFound for an unknown author, got %v", err)
+		}
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		if _, err := NewService(logger, newRepo(), trepo, &fakeUserRepo{}, nil).RebalanceTeam("ghost", false); !errors.Is(err, ErrTeamNotFound) {
+			t.Fatalf("want ErrTeamNotFound got %v", err)
+		}
+	})
+}
+
+func TestPRStatusTransitions(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{members: map[string][]api.TeamMember{"team1": {{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}}}}
+
+	type action func(svc *Service) (*api.PullRequest, error)
+	merge := func(svc *Service) (*api.PullRequest, error) { return svc.MergePR("pr1", false) }
+	closePR := func(svc *Service) (*api.PullRequest, error) { return svc.ClosePR("pr1") }
+	reopen := func(svc *Service) (*api.PullRequest, error) { return svc.ReopenPR("pr1") }
+	markReady := func(svc *Service) (*api.PullRequest, error) {
+		pr, _, err := svc.MarkReady("pr1", api.CreatePROptions{})
+		return pr, err
+	}
+
+	cases := []struct {
+		name       string
+		status     api.PullRequestStatus
+		reviewers  []string
+		act        action
+		wantStatus api.PullRequestStatus
+		wantErr    error
+	}{
+		{name: "ready draft", status: api.PullRequestStatusDRAFT, act: markReady, wantStatus: api.PullRequestStatusOPEN},
+		{name: "close draft", status: api.PullRequestStatusDRAFT, act: closePR, wantStatus: api.PullRequestStatusCLOSED},
+		{name: "merge draft", status: api.PullRequestStatusDRAFT, act: merge, wantErr: ErrInvalidTransition},
+		{name: "ready open", status: api.PullRequestStatusOPEN, reviewers: []string{"u1"}, act: markReady, wantErr: ErrInvalidTransition},
+		{name: "close open", status: api.PullRequestStatusOPEN, reviewers: []string{"u1"}, act: closePR, wantStatus: api.PullRequestStatusCLOSED},
+		{name: "reopen open", status: api.PullRequestStatusOPEN, reviewers: []string{"u1"}, act: reopen, wantErr: ErrInvalidTransition},
+		{name: "reopen closed with reviewers", status: api.PullRequestStatusCLOSED, reviewers: []string{"u1"}, act: reopen, wantStatus: api.PullRequestStatusOPEN},
+		{name: "reopen closed draft", status: api.PullRequestStatusCLOSED, act: reopen, wantStatus: api.PullRequestStatusDRAFT},
+		{name: "merge closed", status: api.PullRequestStatusCLOSED, reviewers: []string{"u1"}, act: merge, wantErr: ErrInvalidTransition},
+		{name: "close merged", status: api.PullRequestStatusMERGED, reviewers: []string{"u1"}, act: closePR, wantErr: ErrInvalidTransition},
+		{name: "merge merged is a no-op", status: api.PullRequestStatusMERGED, reviewers: []string{"u1"}, act: merge, wantStatus: api.PullRequestStatusMERGED},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+				PullRequestId:     "pr1",
+				AuthorId:          "author",
+				Status:            tc.status,
+				AssignedReviewers: tc.reviewers,
+			}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			pr, err := tc.act(svc)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr == nil && pr.Status != tc.wantStatus {
+				t.Fatalf("want status %s got %s", tc.wantStatus, pr.Status)
+			}
+		})
+	}
+}
+
+func TestReopenPR_RechecksReviewers(t *testing.T) {
+	limit := 1
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "team1"},
+		"u1":     {UserId: "u1", TeamName: "team1"},
+		"u2":     {UserId: "u2", TeamName: "team1"},
+		"busy":   {UserId: "busy", TeamName: "team1"},
+		"idle":   {UserId: "idle", TeamName: "team1"},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "author", IsActive: true},
+			{UserId: "u1", IsActive: true},
+			{UserId: "u2", IsActive: true},
+			{UserId: "busy", IsActive: true, MaxOpenReviews: &limit},
+			{UserId: "idle", IsActive: false},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1", RequiredReviewers: 2}},
+	}
+	prrepo := &fakePRRepo{
+		prs: map[string]api.PullRequest{"pr1": {
+			PullRequestId:     "pr1",
+			AuthorId:          "author",
+			Status:            api.PullRequestStatusCLOSED,
+			AssignedReviewers: []string{"idle", "busy"},
+		}},
+		openReviews: map[string]int{"busy": 1},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr, err := svc.ReopenPR("pr1")
+	if err != nil {
+		t.Fatalf("ReopenPR failed: %v", err)
+	}
+	got := slices.Sorted(slices.Values(pr.AssignedReviewers))
+	if pr.Status != api.PullRequestStatusOPEN || !slices.Equal(got, []string{"u1", "u2"}) {
+		t.Fatalf("want OPEN with [u1 u2], got %s with %v", pr.Status, pr.AssignedReviewers)
+	}
+	if len(prrepo.traces) != 1 || prrepo.traces[0].Action != api.AssignmentTraceActionTopUp {
+		t.Fatalf("expected the refill to be traced, got %+v", prrepo.traces)
+	}
+}
+
+func TestCreatePR_DraftWaitsForMarkReady(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{members: map[string][]api.TeamMember{"team1": {{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}}}}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	draft := &api.PullRequest{PullRequestId: "pr1", AuthorId: "author"}
+	if _, err := svc.CreatePR(draft, api.CreatePROptions{Draft: true}); err != nil {
+		t.Fatalf("CreatePR failed: %v", err)
+	}
+	if draft.Status != api.PullRequestStatusDRAFT || len(draft.AssignedReviewers) != 0 || len(prrepo.traces) != 0 {
+		t.Fatalf("expected a draft without reviewers or trace, got %+v and %d traces", draft, len(prrepo.traces))
+	}
+	prrepo.prs["pr1"] = prrepo.created[0]
+
+	if _, _, _, err := svc.ReassignReviewer("pr1", "u1", api.ReassignOptions{}); !errors.Is(err, ErrPRNotOpen) {
+		t.Fatalf("want ErrPRNotOpen on draft got %v", err)
+	}
+	if _, err := svc.AddReviewer("pr1", "u1"); !errors.Is(err, ErrPRNotOpen) {
+		t.Fatalf("want ErrPRNotOpen on draft got %v", err)
+	}
+
+	pr, trace, err := svc.MarkReady("pr1", api.CreatePROptions{})
+	if err != nil {
+		t.Fatalf("MarkReady failed: %v", err)
+	}
+	if pr.Status != api.PullRequestStatusOPEN || len(pr.AssignedReviewers) != 2 || !pr.CreatedAt.Equal(*draft.CreatedAt) {
+		t.Fatalf("unexpected ready PR %+v", pr)
+	}
+	if trace.Action != api.AssignmentTraceActionMarkReady || len(prrepo.traces) != 1 {
+		t.Fatalf("expected a stored mark_ready trace, got %+v", trace)
+	}
+}
+
+func TestGetStatistics_CountsEveryStatus(t *testing.T) {
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{
+		"d1": {PullRequestId: "d1", Status: api.PullRequestStatusDRAFT},
+		"o1": {PullRequestId: "o1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u1", "u2"}},
+		"o2": {PullRequestId: "o2", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u1"}},
+		"m1": {PullRequestId: "m1", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"u2"}},
+		"c1": {PullRequestId: "c1", Status: api.PullRequestStatusCLOSED, AssignedReviewers: []string{"u1"}},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+
+	stats, err := svc.GetStatistics()
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	by := stats.ByStatus
+	if by.Draft != 1 || by.Open != 2 || by.Merged != 1 || by.Closed != 1 || stats.TotalAssignments != 5 {
+		t.Fatalf("unexpected statistics %+v", stats)
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  api.PullRequestStatus
+		userID  string
+		verdict api.ReviewVerdict
+		wantErr error
+	}{
+		{name: "approve", status: api.PullRequestStatusOPEN, userID: "u1", verdict: api.ReviewVerdictAPPROVED},
+		{name: "comment", status: api.PullRequestStatusOPEN, userID: "u1", verdict: api.ReviewVerdictCOMMENTED},
+		{name: "unknown verdict", status: api.PullRequestStatusOPEN, userID: "u1", verdict: "LGTM", wantErr: ErrInvalidVerdict},
+		{name: "not assigned", status: api.PullRequestStatusOPEN, userID: "u2", verdict: api.ReviewVerdictAPPROVED, wantErr: ErrReviewerNotAssigned},
+		{name: "draft", status: api.PullRequestStatusDRAFT, userID: "u1", verdict: api.ReviewVerdictAPPROVED, wantErr: ErrPRNotOpen},
+		{name: "merged", status: api.PullRequestStatusMERGED, userID: "u1", verdict: api.ReviewVerdictAPPROVED, wantErr: ErrPRNotOpen},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+				PullRequestId:     "pr1",
+				AuthorId:          "author",
+				Status:            tc.status,
+				AssignedReviewers: []string{"u1"},
+			}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+
+			review, err := svc.SubmitReview("pr1", tc.userID, tc.verdict)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if len(prrepo.reviews["pr1"]) != 0 {
+					t.Fatalf("expected nothing stored, got %+v", prrepo.reviews["pr1"])
+				}
+				return
+			}
+			if review.Verdict != tc.verdict || len(prrepo.reviews["pr1"]) != 1 {
+				t.Fatalf("unexpected review %+v, stored %+v", review, prrepo.reviews["pr1"])
+			}
+		})
+	}
+}
+
+func TestMergePR_RequiresApprovals(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{teams: map[string]api.Team{"team1": {TeamName: "team1", RequiredApprovals: 2}}}
+
+	cases := []struct {
+		name     string
+		author   string
+		verdicts map[string]api.ReviewVerdict
+		force    bool
+		wantErr  error
+	}{
+		{name: "enough approvals", verdicts: map[string]api.ReviewVerdict{"u1": api.ReviewVerdictAPPROVED, "u2": api.ReviewVerdictAPPROVED}},
+		{name: "one approval", verdicts: map[string]api.ReviewVerdict{"u1": api.ReviewVerdictAPPROVED, "u2": api.ReviewVerdictCOMMENTED}, wantErr: ErrNotEnoughApprovals},
+		{name: "no reviews", wantErr: ErrNotEnoughApprovals},
+		{name: "changes requested", verdicts: map[string]api.ReviewVerdict{"u1": api.ReviewVerdictAPPROVED, "u2": api.ReviewVerdictCHANGESREQUESTED}, wantErr: ErrChangesRequested},
+		{name: "approval of removed reviewer", verdicts: map[string]api.ReviewVerdict{"u1": api.ReviewVerdictAPPROVED, "gone": api.ReviewVerdictAPPROVED}, wantErr: ErrNotEnoughApprovals},
+		{name: "forced", verdicts: map[string]api.ReviewVerdict{"u2": api.ReviewVerdictCHANGESREQUESTED}, force: true},
+		{name: "unknown author", author: "ghost", verdicts: map[string]api.ReviewVerdict{"u1": api.ReviewVerdictAPPROVED, "u2": api.ReviewVerdictAPPROVED}, wantErr: ErrAuthorNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			author := cmp.Or(tc.author, "author")
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+				PullRequestId:     "pr1",
+				AuthorId:          author,
+				Status:            api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{"u1", "u2"},
+			}}}
+			for userID, verdict := range tc.verdicts {
+				_ = prrepo.SaveReview(api.Review{PullRequestId: "pr1", Round: 1, UserId: userID, Verdict: verdict})
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+			pr, err := svc.MergePR("pr1", tc.force)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if len(prrepo.updated) != 0 {
+					t.Fatalf("expected no update, got %+v", prrepo.updated)
+				}
+				return
+			}
+			if pr.Status != api.PullRequestStatusMERGED {
+				t.Fatalf("want MERGED got %s", pr.Status)
+			}
+		})
+	}
+
+	t.Run("later approval clears requested changes", func(t *testing.T) {
+		prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+			PullRequestId:     "pr1",
+			AuthorId:          "author",
+			Status:            api.PullRequestStatusOPEN,
+			AssignedReviewers: []string{"u1", "u2"},
+		}}}
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+		for _, step := range []struct {
+			userID  string
+			verdict api.ReviewVerdict
+		}{
+			{"u1", api.ReviewVerdictAPPROVED},
+			{"u2", api.ReviewVerdictCHANGESREQUESTED},
+			{"u2", api.ReviewVerdictAPPROVED},
+		} {
+			if _, err := svc.SubmitReview("pr1", step.userID, step.verdict); err != nil {
+				t.Fatalf("SubmitReview failed: %v", err)
+			}
+		}
+		if _, err := svc.MergePR("pr1", false); err != nil {
+			t.Fatalf("MergePR failed: %v", err)
+		}
+	})
+}
+
+func TestRequestReReview_StartsNewRound(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{teams: map[string]api.Team{"team1": {TeamName: "team1", RequiredApprovals: 1}}}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+		PullRequestId:     "pr1",
+		AuthorId:          "author",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u1", "u2"},
+		ReviewRound:       1,
+	}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	if _, err := svc.SubmitReview("pr1", "u1", api.ReviewVerdictAPPROVED); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+	if _, err := svc.SubmitReview("pr1", "u2", api.ReviewVerdictCHANGESREQUESTED); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+
+	pr, err := svc.RequestReReview("pr1")
+	if err != nil {
+		t.Fatalf("RequestReReview failed: %v", err)
+	}
+	if pr.ReviewRound != 2 {
+		t.Fatalf("want round 2 got %d", pr.ReviewRound)
+	}
+	prrepo.prs["pr1"] = *pr
+
+	if _, err := svc.MergePR("pr1", false); !errors.Is(err, ErrNotEnoughApprovals) {
+		t.Fatalf("verdicts of round 1 must not count, got %v", err)
+	}
+	if _, err := svc.SubmitReview("pr1", "u2", api.ReviewVerdictAPPROVED); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+
+	reviews, err := svc.GetReviews("pr1")
+	if err != nil {
+		t.Fatalf("GetReviews failed: %v", err)
+	}
+	if reviews.ReviewRound != 2 || len(reviews.Rounds) != 2 {
+		t.Fatalf("unexpected rounds %+v", reviews)
+	}
+	if first := reviews.Rounds[0]; len(first.Reviews) != 2 || len(first.Pending) != 0 {
+		t.Fatalf("unexpected first round %+v", first)
+	}
+	if second := reviews.Rounds[1]; len(second.Reviews) != 1 || second.Reviews[0].UserId != "u2" || !slices.Equal(second.Pending, []string{"u1"}) {
+		t.Fatalf("unexpected second round %+v", second)
+	}
+
+	pr, withPR, err := svc.GetPR("pr1")
+	if err != nil {
+		t.Fatalf("GetPR failed: %v", err)
+	}
+	if pr.PullRequestId != "pr1" || !reflect.DeepEqual(withPR, reviews) {
+		t.Fatalf("expected GetPR to carry the review rounds, got %+v", withPR)
+	}
+
+	if _, err := svc.MergePR("pr1", false); err != nil {
+		t.Fatalf("MergePR failed: %v", err)
+	}
+
+	prrepo.prs["pr1"] = api.PullRequest{PullRequestId: "pr1", Status: api.PullRequestStatusDRAFT}
+	if _, err := svc.RequestReReview("pr1"); !errors.Is(err, ErrPRNotOpen) {
+		t.Fatalf("want ErrPRNotOpen got %v", err)
+	}
+}
+
+func TestGetStatistics_AverageReviewRounds(t *testing.T) {
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{
+		"d1": {PullRequestId: "d1", AuthorId: "a1", Status: api.PullRequestStatusDRAFT, ReviewRound: 1},
+		"o1": {PullRequestId: "o1", AuthorId: "a1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u1"}, ReviewRound: 3},
+		"m1": {PullRequestId: "m1", AuthorId: "a1", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"u1"}, ReviewRound: 1},
+		"m2": {PullRequestId: "m2", AuthorId: "a2", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"u2"}, ReviewRound: 5},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+
+	stats, err := svc.GetStatistics()
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	if stats.AverageReviewRounds != 3 {
+		t.Fatalf("want 3 rounds on average got %v", stats.AverageReviewRounds)
+	}
+	if want := map[string]float64{"a1": 2, "a2": 5}; !maps.Equal(stats.AverageReviewRoundsByAuthor, want) {
+		t.Fatalf("want %v got %v", want, stats.AverageReviewRoundsByAuthor)
+	}
+}
+
+func TestCreatePR_Metadata(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{members: map[string][]api.TeamMember{"team1": {{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}}}}
+
+	cases := []struct {
+		name    string
+		pr      api.PullRequest
+		opts    api.CreatePROptions
+		want    api.PullRequest
+		wantErr error
+	}{
+		{
+			name: "stored as given",
+			pr: api.PullRequest{
+				Repository: "org/api", Url: "https://git.example.com/org/api/pull/7",
+				SourceBranch: "fix-login", TargetBranch: "main", Labels: []string{"bug"},
+				LinesAdded: 40, LinesRemoved: 12, FilesChanged: 3,
+			},
+			want: api.PullRequest{
+				Repository: "org/api", Url: "https://git.example.com/org/api/pull/7",
+				SourceBranch: "fix-login", TargetBranch: "main", Labels: []string{"bug"},
+				LinesAdded: 40, LinesRemoved: 12, FilesChanged: 3,
+			},
+		},
+		{
+			name: "filled from options",
+			opts: api.CreatePROptions{Repository: "org/api", ChangedFiles: []string{"a.go", "b.go"}},
+			want: api.PullRequest{Repository: "org/api", FilesChanged: 2, Labels: []string{}},
+		},
+		{
+			name: "labels trimmed and deduplicated",
+			pr:   api.PullRequest{Labels: []string{" bug", "", "bug", "security "}},
+			want: api.PullRequest{Labels: []string{"bug", "security"}},
+		},
+		{name: "negative size", pr: api.PullRequest{LinesAdded: -1}, wantErr: ErrInvalidPRSize},
+		{name: "relative url", pr: api.PullRequest{Url: "/org/api/pull/7"}, wantErr: ErrInvalidPRURL},
+		{name: "non-http url", pr: api.PullRequest{Url: "ftp://example.com/pull/7"}, wantErr: ErrInvalidPRURL},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, &fakeCodeOwnersRepo{})
+
+			pr := tc.pr
+			pr.PullRequestId, pr.AuthorId = "pr1", "author"
+			_, err := svc.CreatePR(&pr, tc.opts)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if len(prrepo.created) != 0 {
+					t.Fatalf("expected nothing stored, got %+v", prrepo.created)
+				}
+				return
+			}
+
+			got := prrepo.created[0]
+			if got.Repository != tc.want.Repository || got.Url != tc.want.Url ||
+				got.SourceBranch != tc.want.SourceBranch || got.TargetBranch != tc.want.TargetBranch ||
+				!slices.Equal(got.Labels, tc.want.Labels) || got.LinesAdded != tc.want.LinesAdded ||
+				got.LinesRemoved != tc.want.LinesRemoved || got.FilesChanged != tc.want.FilesChanged {
+				t.Fatalf("want metadata %+v got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFindPRsByReviewer_PassesFilter(t *testing.T) {
+	prrepo := &fakePRRepo{prsByReviewer: map[string][]api.PullRequest{"u1": {{PullRequestId: "pr1", Repository: "org/api"}}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+	hundred := 100
+
+	filter := api.PullRequestFilter{Repository: "org/api", Label: "bug", MaxSize: &hundred}
+	prs, err := svc.FindPRsByReviewer("u1", filter)
+	if err != nil {
+		t.Fatalf("FindPRsByReviewer failed: %v", err)
+	}
+	if len(prs) != 1 || !reflect.DeepEqual(prrepo.reviewerFilter, filter) {
+		t.Fatalf("expected the filter to reach the repository, got %+v", prrepo.reviewerFilter)
+	}
+}
+
+func TestFindPRByID_Table(t *testing.T) {
+	dbErr := repositoryError("connection refused")
+
+	cases := []struct {
+		name    string
+		prID    string
+		findErr error
+		wantErr error
+	}{
+		{"found", "pr1", nil, nil},
+		{"missing", "pr2", nil, ErrPRNotFound},
+		{"repository failure", "pr1", dbErr, dbErr},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {PullRequestId: "pr1", Status: api.PullRequestStatusOPEN}}, findErr: tc.findErr}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+
+			for _, find := range []func() error{
+				func() error { _, err := svc.FindPRByID(tc.prID); return err },
+				func() error { _, err := svc.ClosePR(tc.prID); return err },
+			} {
+				err := find()
+				if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil && err != nil) {
+					t.Fatalf("want %v got %v", tc.wantErr, err)
+				}
+				if tc.findErr != nil && errors.Is(err, ErrPRNotFound) {
+					t.Fatalf("repository failure reported as not found: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetStatistics_ByRepository(t *testing.T) {
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{
+		"a1": {PullRequestId: "a1", Repository: "org/api", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u1", "u2"}, LinesAdded: 30, LinesRemoved: 10},
+		"a2": {PullRequestId: "a2", Repository: "org/api", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"u1"}, LinesAdded: 20},
+		"w1": {PullRequestId: "w1", Repository: "org/web", Status: api.PullRequestStatusDRAFT},
+		"n1": {PullRequestId: "n1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+
+	stats, err := svc.GetStatistics()
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	want := map[string]api.RepositoryStatistics{
+		"org/api": {PullRequests: 2, Open: 1, Merged: 1, TotalAssignments: 3, AverageSize: 30},
+		"org/web": {PullRequests: 1},
+	}
+	if !maps.Equal(stats.ByRepository, want) {
+		t.Fatalf("want %+v got %+v", want, stats.ByRepository)
+	}
+}
+
+func TestCreatePR_SizeThresholds(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true},
+			{UserId: "u3", IsActive: true}, {UserId: "u4", IsActive: true},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1", SizeThresholds: []api.SizeThreshold{
+			{MinLines: 0, Reviewers: 1},
+			{MinLines: 50, Reviewers: 2},
+			{MinLines: 501, Reviewers: 3},
+		}}},
+	}
+
+	cases := []struct {
+		name         string
+		linesAdded   int
+		linesRemoved int
+		want         int
+	}{
+		{name: "small", linesAdded: 30, linesRemoved: 19, want: 1},
+		{name: "medium", linesAdded: 40, linesRemoved: 10, want: 2},
+		{name: "at upper bound", linesAdded: 500, want: 2},
+		{name: "large", linesAdded: 400, linesRemoved: 200, want: 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, &fakePRRepo{}, trepo, urepo, nil)
+
+			pr := api.PullRequest{PullRequestId: "pr1", AuthorId: "author", LinesAdded: tc.linesAdded, LinesRemoved: tc.linesRemoved}
+			if _, err := svc.CreatePR(&pr, api.CreatePROptions{}); err != nil {
+				t.Fatalf("CreatePR failed: %v", err)
+			}
+			if len(pr.AssignedReviewers) != tc.want {
+				t.Fatalf("want %d reviewers got %v", tc.want, pr.AssignedReviewers)
+			}
+		})
+	}
+}
+
+func TestUpdatePRSize_TopsUpReviewers(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{"author": {UserId: "author", TeamName: "team1"}}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}, {UserId: "u3", IsActive: true},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1", RequiredReviewers: 1, SizeThresholds: []api.SizeThreshold{
+			{MinLines: 500, Reviewers: 3},
+		}}},
+	}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{
+		"open": {
+			PullRequestId:     "open",
+			AuthorId:          "author",
+			Status:            api.PullRequestStatusOPEN,
+			AssignedReviewers: []string{"u1"},
+			Reviewers:         []api.ReviewerAssignment{{UserId: "u1", TeamName: "team1", Source: api.ReviewerSourceAuto}},
+			LinesAdded:        20,
+		},
+		"draft":  {PullRequestId: "draft", AuthorId: "author", Status: api.PullRequestStatusDRAFT, AssignedReviewers: []string{}},
+		"merged": {PullRequestId: "merged", AuthorId: "author", Status: api.PullRequestStatusMERGED},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr, trace, err := svc.UpdatePRSize("open", 10, 5, nil, api.CreatePROptions{})
+	if err != nil {
+		t.Fatalf("UpdatePRSize failed: %v", err)
+	}
+	if trace != nil || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("small PR must keep its reviewers, got %v", pr.AssignedReviewers)
+	}
+
+	files := 12
+	pr, trace, err = svc.UpdatePRSize("open", 450, 150, &files, api.CreatePROptions{})
+	if err != nil {
+		t.Fatalf("UpdatePRSize failed: %v", err)
+	}
+	if prSize(*pr) != 600 || pr.FilesChanged != 12 {
+		t.Fatalf("size not updated: %+v", pr)
+	}
+	if !slices.Equal(pr.AssignedReviewers, []string{"u1", "u2", "u3"}) && !slices.Equal(pr.AssignedReviewers, []string{"u1", "u3", "u2"}) {
+		t.Fatalf("want u1 kept and two reviewers added, got %v", pr.AssignedReviewers)
+	}
+	if trace == nil || trace.Action != api.AssignmentTraceActionTopUp || len(prrepo.traces) != 1 {
+		t.Fatalf("want a stored top_up trace, got %+v", trace)
+	}
+
+	pr, trace, err = svc.UpdatePRSize("draft", 900, 0, nil, api.CreatePROptions{})
+	if err != nil {
+		t.Fatalf("UpdatePRSize failed: %v", err)
+	}
+	if trace != nil || len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("drafts get reviewers only when marked ready, got %v", pr.AssignedReviewers)
+	}
+
+	if _, _, err := svc.UpdatePRSize("merged", 10, 0, nil, api.CreatePROptions{}); !errors.Is(err, ErrCannotUpdateMergedPR) {
+		t.Fatalf("want ErrCannotUpdateMergedPR got %v", err)
+	}
+	if _, _, err := svc.UpdatePRSize("open", -1, 0, nil, api.CreatePROptions{}); !errors.Is(err, ErrInvalidPRSize) {
+		t.Fatalf("want ErrInvalidPRSize got %v", err)
+	}
+	if _, _, err := svc.UpdatePRSize("missing", 1, 0, nil, api.CreatePROptions{}); !errors.Is(err, ErrPRNotFound) {
+		t.Fatalf("want ErrPRNotFound got %v", err)
+	}
+}
+
+func TestUpdatePRSize_TopUpStages(t *testing.T) {
+	members := []api.TeamMember{
+		{UserId: "author", IsActive: true},
+		{UserId: "j1", IsActive: true, Seniority: api.SeniorityJunior},
+		{UserId: "j2", IsActive: true, Seniority: api.SeniorityJunior},
+	}
+	cases := []struct {
+		name          string
+		requireSenior bool
+		opts          api.CreatePROptions
+		want          []string
+		shortfall     int
+	}{
+		{"code owners come first", false, api.CreatePROptions{ChangedFiles: []string{"db/001.sql"}}, []string{"j1", "d1", "j2"}, 0},
+		{"no senior keeps the size", true, api.CreatePROptions{}, []string{"j1"}, 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urepo := &fakeUserRepo{users: map[string]api.User{
+				"author": {UserId: "author", TeamName: "team1"},
+				"j1":     {UserId: "j1", TeamName: "team1", Seniority: api.SeniorityJunior},
+				"j2":     {UserId: "j2", TeamName: "team1", Seniority: api.SeniorityJunior},
+			}}
+			trepo := &fakeTeamRepo{
+				members: map[string][]api.TeamMember{"team1": members, "dba": {{UserId: "d1", IsActive: true}}},
+				teams: map[string]api.Team{
+					"team1": {TeamName: "team1", RequiredReviewers: 1, RequireSenior: tc.requireSenior, SizeThresholds: []api.SizeThreshold{{MinLines: 500, Reviewers: 3}}},
+					"dba":   {TeamName: "dba"},
+				},
+			}
+			corepo := &fakeCodeOwnersRepo{rules: map[string][]api.CodeOwnersRule{"svc": {{Pattern: "*.sql", Owners: []string{"@dba"}}}}}
+			prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+				PullRequestId:     "pr1",
+				AuthorId:          "author",
+				Repository:        "svc",
+				Status:            api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{"j1"},
+			}}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			svc := NewService(logger, prrepo, trepo, urepo, corepo)
+
+			pr, trace, err := svc.UpdatePRSize("pr1", 600, 0, nil, tc.opts)
+			if err != nil {
+				t.Fatalf("UpdatePRSize failed: %v", err)
+			}
+			if !slices.Equal(pr.AssignedReviewers, tc.want) {
+				t.Fatalf("want reviewers %v got %v", tc.want, pr.AssignedReviewers)
+			}
+			if stored := prrepo.prs["pr1"]; stored.LinesAdded != 600 {
+				t.Fatalf("size not stored: %+v", stored)
+			}
+			if trace == nil || trace.Shortfall != tc.shortfall || trace.CapacityExhausted {
+				t.Fatalf("want shortfall %d without capacity limits got %+v", tc.shortfall, trace)
+			}
+		})
+	}
+}
+
+func TestReopenPR_TopsUpResizedPR(t *testing.T) {
+	urepo := &fakeUserRepo{users: map[string]api.User{
+		"author": {UserId: "author", TeamName: "team1"},
+		"u1":     {UserId: "u1", TeamName: "team1"},
+	}}
+	trepo := &fakeTeamRepo{
+		members: map[string][]api.TeamMember{"team1": {
+			{UserId: "author", IsActive: true}, {UserId: "u1", IsActive: true}, {UserId: "u2", IsActive: true}, {UserId: "u3", IsActive: true},
+		}},
+		teams: map[string]api.Team{"team1": {TeamName: "team1", RequiredReviewers: 1, SizeThresholds: []api.SizeThreshold{{MinLines: 500, Reviewers: 3}}}},
+	}
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{"pr1": {
+		PullRequestId:     "pr1",
+		AuthorId:          "author",
+		Status:            api.PullRequestStatusCLOSED,
+		AssignedReviewers: []string{"u1"},
+	}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, trepo, urepo, nil)
+
+	pr, trace, err := svc.UpdatePRSize("pr1", 700, 0, nil, api.CreatePROptions{})
+	if err != nil {
+		t.Fatalf("UpdatePRSize failed: %v", err)
+	}
+	if trace != nil || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("closed PRs are topped up on reopen, got %v", pr.AssignedReviewers)
+	}
+
+	pr, err = svc.ReopenPR("pr1")
+	if err != nil {
+		t.Fatalf("ReopenPR failed: %v", err)
+	}
+	got := slices.Sorted(slices.Values(pr.AssignedReviewers))
+	if pr.Status != api.PullRequestStatusOPEN || !slices.Equal(got, []string{"u1", "u2", "u3"}) {
+		t.Fatalf("want OPEN with [u1 u2 u3], got %s with %v", pr.Status, pr.AssignedReviewers)
+	}
+}
+
+func TestListPRs_Pagination(t *testing.T) {
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	prrepo := &fakePRRepo{prs: map[string]api.PullRequest{}}
+	for i, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		at := created.Add(time.Duration(i) * time.Hour)
+		prrepo.prs[id] = api.PullRequest{PullRequestId: id, AuthorId: "author", Status: api.PullRequestStatusOPEN, CreatedAt: &at}
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, prrepo, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+
+	var pages [][]string
+	query := api.PullRequestListQuery{Limit: 2}
+	for {
+		page, err := svc.ListPRs(query)
+		if err != nil {
+			t.Fatalf("ListPRs failed: %v", err)
+		}
+		var ids []string
+		for _, pr := range page.PullRequests {
+			ids = append(ids, pr.PullRequestId)
+		}
+		pages = append(pages, ids)
+		if page.NextCursor == nil {
+			break
+		}
+		query.Cursor = *page.NextCursor
+	}
+
+	want := [][]string{{"p1", "p2"}, {"p3", "p4"}, {"p5"}}
+	if !slices.EqualFunc(pages, want, slices.Equal[[]string]) {
+		t.Fatalf("want pages %v got %v", want, pages)
+	}
+	if q := prrepo.listQuery; q.Limit != 3 || q.Sort != api.PullRequestSortCreatedAt || q.Order != api.SortOrderDesc {
+		t.Fatalf("unexpected repository query %+v", q)
+	}
+
+	page, err := svc.ListPRs(api.PullRequestListQuery{Limit: 1})
+	if err != nil {
+		t.Fatalf("ListPRs failed: %v", err)
+	}
+	cursor, err := decodeCursor(*page.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeCursor failed: %v", err)
+	}
+	if cursor.PullRequestId != "p1" || cursor.Key != created.Format(time.RFC3339Nano) {
+		t.Fatalf("unexpected cursor %+v", cursor)
+	}
+}
+
+func TestListPRs_InvalidQuery(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewService(logger, &fakePRRepo{}, &fakeTeamRepo{}, &fakeUserRepo{}, nil)
+	from := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+	sizeCursor := encodeCursor(api.PullRequestCursor{Sort: api.PullRequestSortSize, Order: api.SortOrderDesc, Key: "10", PullRequestId: "p1"})
+	badKey := encodeCursor(api.PullRequestCursor{Sort: api.PullRequestSortCreatedAt, Order: api.SortOrderDesc, Key: "yesterday", PullRequestId: "p1"})
+
+	cases := []struct {
+		name    string
+		query   api.PullRequestListQuery
+		wantErr error
+	}{
+		{name: "unknown status", query: api.PullRequestListQuery{Status: "REVIEWING"}, wantErr: ErrInvalidPRStatus},
+		{name: "unknown sort", query: api.PullRequestListQuery{Sort: "name"}, wantErr: ErrInvalidSort},
+		{name: "unknown order", query: api.PullRequestListQuery{Order: "up"}, wantErr: ErrInvalidSort},
+		{name: "limit too large", query: api.PullRequestListQuery{Limit: 101}, wantErr: ErrInvalidLimit},
+		{name: "negative limit", query: api.PullRequestListQuery{Limit: -1}, wantErr: ErrInvalidLimit},
+		{name: "inverted range", query: api.PullRequestListQuery{CreatedFrom: &from, CreatedTo: &to}, wantErr: ErrInvalidDateRange},
+		{name: "garbage cursor", query: api.PullRequestListQuery{Cursor: "not a cursor"}, wantErr: ErrInvalidCursor},
+		{name: "cursor of another sort", query: api.PullRequestListQuery{Cursor: sizeCursor}, wantErr: ErrInvalidCursor},
+		{name: "cursor with bad key", query: api.PullRequestListQuery{Cursor: badKey}, wantErr: ErrInvalidCursor},
+		{name: "size cursor", query: api.PullRequestListQuery{Sort: api.PullRequestSortSize, Cursor: sizeCursor}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := svc.ListPRs(tc.query); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
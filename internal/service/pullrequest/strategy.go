@@ -0,0 +1,212 @@
+package pullrequest
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+// AssignmentContext describes the pull request reviewers are being picked for.
+type AssignmentContext struct {
+	PullRequest api.PullRequest
+	TeamName    string
+	Seats       int
+	OpenReviews map[string]int
+	At          time.Time
+	// RecentPairings counts, per candidate, the author's PRs they reviewed
+	// within the team's lookback window. Nil unless the team avoids repeats.
+	// Strategies down-weight candidates by it rather than ranking by it.
+	RecentPairings map[string]int
+	// Rand is the randomness of the assignment; nil draws from a fresh seed.
+	Rand *rand.Rand
+	// DryRun marks a preview: the picks are not recorded with the strategy.
+	DryRun bool
+}
+
+// load is the candidate's open reviews plus their recent reviews of the
+// author, so every repeat pairing weighs like one more open review.
+func (a AssignmentContext) load(userID string) int {
+	return a.OpenReviews[userID] + a.RecentPairings[userID]
+}
+
+func (a AssignmentContext) random() *rand.Rand {
+	if a.Rand != nil {
+		return a.Rand
+	}
+	return seededRand(cryptoSource{}.NextSeed())
+}
+
+// AssignmentStrategy orders reviewer candidates from most to least preferred.
+type AssignmentStrategy interface {
+	Order(assignment AssignmentContext, candidates []api.TeamMember) []string
+}
+
+// pickRecorder is implemented by strategies that remember what they picked.
+// It is told the final picks of every assignment that is not a dry run.
+type pickRecorder interface {
+	Picked(assignment AssignmentContext, picked []string)
+}
+
+// recordPicks tells a strategy that remembers its picks what was finally
+// picked, unless the assignment is a dry run.
+func recordPicks(strategy AssignmentStrategy, assignment AssignmentContext, picked []string) {
+	if recorder, ok := strategy.(pickRecorder); ok && !assignment.DryRun {
+		recorder.Picked(assignment, picked)
+	}
+}
+
+func newStrategies() map[api.TeamAssignmentStrategy]AssignmentStrategy {
+	return map[api.TeamAssignmentStrategy]AssignmentStrategy{
+		api.TeamAssignmentStrategyRandom:         randomStrategy{},
+		api.TeamAssignmentStrategyRoundRobin:     &roundRobinStrategy{last: make(map[string]string)},
+		api.TeamAssignmentStrategyLeastLoaded:    leastLoadedStrategy{},
+		api.TeamAssignmentStrategyWeightedRandom: weightedRandomStrategy{},
+	}
+}
+
+// randomStrategy picks uniformly at random. Candidates who recently reviewed
+// the author are drawn with a weight of 1/(1+recent pairings).
+type randomStrategy struct{}
+
+func (randomStrategy) Order(assignment AssignmentContext, candidates []api.TeamMember) []string {
+	if len(assignment.RecentPairings) == 0 {
+		return memberIDs(shuffleMembers(assignment.random(), candidates))
+	}
+	return weightedOrder(assignment.random(), candidates, func(m api.TeamMember) float64 {
+		return 1 / float64(1+assignment.RecentPairings[m.UserId])
+	})
+}
+
+// roundRobinStrategy hands out seats in user_id order, continuing after the
+// last reviewer it picked for the team. Every recent review of the author
+// moves a candidate one seat later in the rotation.
+type roundRobinStrategy struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func (s *roundRobinStrategy) Order(assignment AssignmentContext, candidates []api.TeamMember) []string {
+	ids := memberIDs(candidates)
+	if len(ids) == 0 {
+		return ids
+	}
+	sort.Strings(ids)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := sort.SearchStrings(ids, s.last[assignment.TeamName])
+	if start < len(ids) && ids[start] == s.last[assignment.TeamName] {
+		start++
+	}
+	start %= len(ids)
+	ordered := append(ids[start:len(ids):len(ids)], ids[:start]...)
+
+	if len(assignment.RecentPairings) > 0 {
+		seat := make(map[string]int, len(ordered))
+		for i, id := range ordered {
+			seat[id] = i + assignment.RecentPairings[id]
+		}
+		slices.SortStableFunc(ordered, func(a, b string) int { return cmp.Compare(seat[a], seat[b]) })
+	}
+	return ordered
+}
+
+// Picked continues the rotation after the pick that comes last in it, which
+// is the last pick in user_id order after wrapping around the previous one.
+func (s *roundRobinStrategy) Picked(assignment AssignmentContext, picked []string) {
+	if len(picked) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last := s.last[assignment.TeamName]
+	lap := func(id string) int {
+		if id > last {
+			return 0
+		}
+		return 1
+	}
+	s.last[assignment.TeamName] = slices.MaxFunc(picked, func(a, b string) int {
+		return cmp.Or(cmp.Compare(lap(a), lap(b)), cmp.Compare(a, b))
+	})
+}
+
+// leastLoadedStrategy prefers the candidates with the lowest load, counting
+// recent reviews of the author as open reviews.
+type leastLoadedStrategy struct{}
+
+func (leastLoadedStrategy) Order(assignment AssignmentContext, candidates []api.TeamMember) []string {
+	shuffled := shuffleMembers(assignment.random(), candidates)
+	sort.SliceStable(shuffled, func(i, j int) bool {
+		return assignment.load(shuffled[i].UserId) < assignment.load(shuffled[j].UserId)
+	})
+	return memberIDs(shuffled)
+}
+
+// weightedRandomStrategy draws candidates at random with a weight of
+// 1/(1+load), so busy reviewers and recent pairings are picked less often
+// but not never.
+type weightedRandomStrategy struct{}
+
+func (weightedRandomStrategy) Order(assignment AssignmentContext, candidates []api.TeamMember) []string {
+	return weightedOrder(assignment.random(), candidates, func(m api.TeamMember) float64 {
+		return 1 / float64(1+assignment.load(m.UserId))
+	})
+}
+
+// weightedOrder draws the candidates one by one, each with a chance
+// proportional to its weight among those left.
+func weightedOrder(rng *rand.Rand, candidates []api.TeamMember, weight func(api.TeamMember) float64) []string {
+	pool := make([]api.TeamMember, len(candidates))
+	copy(pool, candidates)
+
+	out := make([]string, 0, len(pool))
+	for len(pool) > 0 {
+		weights := make([]float64, len(pool))
+		total := 0.0
+		for i, m := range pool {
+			weights[i] = weight(m)
+			total += weights[i]
+		}
+
+		pick := len(pool) - 1
+		target := rng.Float64() * total
+		for i, w := range weights {
+			if target < w {
+				pick = i
+				break
+			}
+			target -= w
+		}
+
+		out = append(out, pool[pick].UserId)
+		pool = append(pool[:pick], pool[pick+1:]...)
+	}
+	return out
+}
+
+func shuffleMembers(rng *rand.Rand, members []api.TeamMember) []api.TeamMember {
+	shuffled := make([]api.TeamMember, len(members))
+	copy(shuffled, members)
+
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := rng.IntN(i + 1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled
+}
+
+func memberIDs(members []api.TeamMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.UserId)
+	}
+	return ids
+}
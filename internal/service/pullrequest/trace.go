@@ -0,0 +1,109 @@
+package pullrequest
+
+import (
+	"slices"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+// newStep starts a trace step for the assignment, or returns nil when the
+// assignment is not traced.
+func (d *draw) newStep(stage api.AssignmentTraceStage, teamName string, strategy api.TeamAssignmentStrategy, seats int) *api.AssignmentTraceStep {
+	if d == nil || d.trace == nil {
+		return nil
+	}
+	return &api.AssignmentTraceStep{
+		Stage:      stage,
+		TeamName:   teamName,
+		Strategy:   strategy,
+		Seats:      seats,
+		Candidates: []api.TraceCandidate{},
+		Picked:     []string{},
+	}
+}
+
+// changeTrace starts the trace of a reviewer change made without a draw, such
+// as adding or removing a reviewer by hand or a rebalance move. reviewers are
+// the PR's reviewers after the change.
+func (s *Service) changeTrace(prID string, action api.AssignmentTraceAction, reviewers []string) *api.AssignmentTrace {
+	return &api.AssignmentTrace{
+		PullRequestId: prID,
+		Action:        action,
+		At:            s.now(),
+		Reviewers:     reviewers,
+		Steps:         []api.AssignmentTraceStep{},
+	}
+}
+
+// record appends a finished step to the trace.
+func (d *draw) record(step *api.AssignmentTraceStep) {
+	if step != nil {
+		d.trace.Steps = append(d.trace.Steps, *step)
+	}
+}
+
+// traceCandidates lists every member of the pool's team together with the
+// reason it cannot be picked, if any.
+func traceCandidates(pool *teamPool, taken []string, exclude func(api.TeamMember) api.CandidateExclusion) []api.TraceCandidate {
+	candidates := make([]api.TraceCandidate, 0, len(pool.all))
+	for _, m := range pool.all {
+		reason := pool.excluded[m.UserId]
+		if reason != api.CandidateExclusionAuthor && slices.Contains(taken, m.UserId) {
+			reason = api.CandidateExclusionAlreadyAssigned
+		}
+		if reason == "" && exclude != nil {
+			reason = exclude(m)
+		}
+		candidates = append(candidates, api.TraceCandidate{
+			UserId:      m.UserId,
+			Excluded:    reason,
+			OpenReviews: pool.openReviews[m.UserId],
+		})
+	}
+	return candidates
+}
+
+// traceCandidate returns the step's entry for the user, or nil.
+func traceCandidate(step *api.AssignmentTraceStep, userID string) *api.TraceCandidate {
+	if step == nil {
+		return nil
+	}
+	for i := range step.Candidates {
+		if step.Candidates[i].UserId == userID {
+			return &step.Candidates[i]
+		}
+	}
+	return nil
+}
+
+// backfillCandidates lists the replacements considered when a deactivation
+// leaves a PR short of reviewers, with the reason each one outside eligible
+// could not be picked.
+func backfillCandidates(
+	replacements, eligible []api.TeamMember,
+	authorID string,
+	taken []string,
+	openReviews map[string]int,
+	exclude func(api.TeamMember) api.CandidateExclusion,
+) []api.TraceCandidate {
+	candidates := make([]api.TraceCandidate, 0, len(replacements))
+	for _, m := range replacements {
+		var reason api.CandidateExclusion
+		switch {
+		case m.UserId == authorID:
+			reason = api.CandidateExclusionAuthor
+		case slices.Contains(taken, m.UserId):
+			reason = api.CandidateExclusionAlreadyAssigned
+		case !slices.ContainsFunc(eligible, func(e api.TeamMember) bool { return e.UserId == m.UserId }):
+			reason = api.CandidateExclusionAtCapacity
+		case exclude != nil:
+			reason = exclude(m)
+		}
+		candidates = append(candidates, api.TraceCandidate{
+			UserId:      m.UserId,
+			Excluded:    reason,
+			OpenReviews: openReviews[m.UserId],
+		})
+	}
+	return candidates
+}
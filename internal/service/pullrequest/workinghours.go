@@ -0,0 +1,50 @@
+package pullrequest
+
+import (
+	"time"
+
+	"github.com/V1merX/pr-reviewer-service/internal/api"
+)
+
+const minutesPerDay = 24 * 60
+
+// atWork reports whether the reviewer is inside their working hours at the
+// given moment or starts work within lookahead. Reviewers without (valid)
+// working hours are always considered at work. Working hours repeat daily; an
+// end before the start describes a window that crosses midnight.
+func atWork(hours api.WorkingHours, at time.Time, lookahead time.Duration) bool {
+	loc, err := time.LoadLocation(hours.Timezone)
+	if err != nil {
+		return true
+	}
+	start, err := minuteOfDay(hours.Start)
+	if err != nil {
+		return true
+	}
+	end, err := minuteOfDay(hours.End)
+	if err != nil || start == end {
+		return true
+	}
+
+	local := at.In(loc)
+	now := local.Hour()*60 + local.Minute()
+
+	inside := now >= start && now < end
+	if end < start {
+		inside = now >= start || now < end
+	}
+	if inside {
+		return true
+	}
+
+	untilStart := (start - now + minutesPerDay) % minutesPerDay
+	return time.Duration(untilStart)*time.Minute <= lookahead
+}
+
+func minuteOfDay(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
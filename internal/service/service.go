@@ -5,21 +5,54 @@ import "github.com/V1merX/pr-reviewer-service/internal/api"
 type UserService interface {
 	GetUserByID(userID string) (*api.User, error)
 	SetUserStatus(userID string, status bool) (*api.User, error)
+	SetUserSkills(userID string, skills []string) (*api.User, error)
+	SetMaxOpenReviews(userID string, limit *int) (*api.User, error)
+	SetWorkingHours(userID string, hours *api.WorkingHours) (*api.User, error)
+	SetSeniority(userID string, seniority api.Seniority) (*api.User, error)
+	AddUnavailability(req api.PostUsersAddUnavailabilityJSONBody) (*api.Unavailability, error)
+	GetUnavailability(userID string) ([]api.Unavailability, error)
+	UpdateUnavailability(req api.PostUsersUpdateUnavailabilityJSONBody) (*api.Unavailability, error)
+	DeleteUnavailability(id int64) error
 }
 
 type PullRequestService interface {
 	GetActiveTeamMembers(authorID string) ([]api.TeamMember, error)
-	SelectRandomReviewers(members []api.TeamMember, count int) []string
-	CreatePR(pr *api.PullRequest) error
+	SelectRandomReviewers(members []api.TeamMember, count int, requireSenior bool) []string
+	SelectLeastLoadedReviewers(members []api.TeamMember, openReviews map[string]int, count int) []string
+	CreatePR(pr *api.PullRequest, opts api.CreatePROptions) (*api.AssignmentTrace, error)
+	PreviewPR(pr *api.PullRequest, opts api.CreatePROptions) (*api.AssignmentPreview, error)
 	FindPRByID(prID string) (*api.PullRequest, error)
-	MergePR(prID string) (*api.PullRequest, error)
-	FindPRsByReviewer(userID string) ([]api.PullRequest, error)
-	ReassignReviewer(prID string, oldReviewerID string) (*api.PullRequest, *string, error)
+	GetPR(prID string) (*api.PullRequest, *api.PullRequestReviews, error)
+	MergePR(prID string, force bool) (*api.PullRequest, error)
+	SubmitReview(prID string, userID string, verdict api.ReviewVerdict) (*api.Review, error)
+	RequestReReview(prID string) (*api.PullRequest, error)
+	UpdatePRSize(prID string, linesAdded, linesRemoved int, filesChanged *int, opts api.CreatePROptions) (*api.PullRequest, *api.AssignmentTrace, error)
+	GetReviews(prID string) (*api.PullRequestReviews, error)
+	MarkReady(prID string, opts api.CreatePROptions) (*api.PullRequest, *api.AssignmentTrace, error)
+	ClosePR(prID string) (*api.PullRequest, error)
+	ReopenPR(prID string) (*api.PullRequest, error)
+	FindPRsByReviewer(userID string, filter api.PullRequestFilter) ([]api.PullRequest, error)
+	ListPRs(query api.PullRequestListQuery) (*api.PullRequestPage, error)
+	ReassignReviewer(prID string, oldReviewerID string, opts api.ReassignOptions) (*api.PullRequest, *string, *api.AssignmentTrace, error)
+	AddReviewer(prID string, userID string) (*api.PullRequest, error)
+	RemoveReviewer(prID string, userID string) (*api.PullRequest, error)
 	GetStatistics() (*api.Statistics, error)
 	DeactivateUsersAndReassignPRs(teamName string, userIDs []string) (*api.BatchDeactivateResponse, error)
+	ReassignOpenReviews(userID string) ([]api.ReviewReassignment, error)
+	HandOverReviews(unavailability api.Unavailability) ([]api.ReviewReassignment, error)
+	DeactivateUser(userID string, reassignReviews bool) (*api.UserDeactivation, error)
+	GetPairingMatrix(teamName string, lookbackDays *int) (*api.PairingMatrix, error)
+	RebalanceTeam(teamName string, apply bool) (*api.TeamRebalance, error)
+	GetAssignmentTraces(prID string) ([]api.AssignmentTrace, error)
 }
 
 type TeamService interface {
 	GetTeamByName(teamName string) (*api.Team, error)
 	AddTeam(team *api.Team) error
+	UpdateTeamSettings(req api.PostTeamUpdateJSONBody) (*api.Team, error)
+}
+
+type CodeOwnersService interface {
+	SetCodeOwners(repository, content string) (*api.CodeOwners, error)
+	GetCodeOwners(repository string) (*api.CodeOwners, error)
 }
@@ -0,0 +1,28 @@
+// Package skill holds the skill tag rules shared by the user and pull request
+// services.
+package skill
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid skill")
+
+// Normalize lower-cases and trims skill tags and drops duplicates, keeping the
+// first-seen order. Empty tags or tags with inner spaces are rejected.
+func Normalize(skills []string) ([]string, error) {
+	seen := make(map[string]bool, len(skills))
+	normalized := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || strings.ContainsAny(skill, " \t\n") {
+			return nil, ErrInvalid
+		}
+		if !seen[skill] {
+			seen[skill] = true
+			normalized = append(normalized, skill)
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,34 @@
+package skill
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalize_Table(t *testing.T) {
+	cases := []struct {
+		name    string
+		skills  []string
+		want    []string
+		wantErr error
+	}{
+		{"no skills", nil, []string{}, nil},
+		{"lower-cased and trimmed", []string{" Go ", "SQL"}, []string{"go", "sql"}, nil},
+		{"duplicates dropped in order", []string{"go", "sql", "GO"}, []string{"go", "sql"}, nil},
+		{"empty tag", []string{"go", " "}, nil, ErrInvalid},
+		{"tag with space", []string{"front end"}, nil, ErrInvalid},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Normalize(tc.skills)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want err %v got %v", tc.wantErr, err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
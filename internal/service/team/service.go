@@ -4,14 +4,27 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
 	"github.com/V1merX/pr-reviewer-service/internal/repository"
+	"github.com/V1merX/pr-reviewer-service/internal/service"
 )
 
 var (
-	ErrTeamNotFound = errors.New("team not found")
-	ErrTeamExists   = errors.New("team already exists")
+	ErrTeamNotFound              = errors.New("team not found")
+	ErrTeamExists                = errors.New("team already exists")
+	ErrInvalidAssignmentStrategy = errors.New("unknown assignment strategy")
+	ErrInvalidRequiredReviewers  = errors.New("required reviewers must be positive")
+	ErrInvalidFallbackTeams      = errors.New("invalid fallback teams")
+	ErrInvalidMaxOpenReviews     = errors.New("max open reviews must not be negative")
+	ErrInvalidWorkingHoursMode   = errors.New("unknown working hours mode")
+	ErrInvalidLookahead          = errors.New("working hours lookahead must not be negative")
+	ErrInvalidSeniority          = errors.New("unknown seniority")
+	ErrInvalidPairingMode        = errors.New("unknown pairing mode")
+	ErrInvalidPairingLookback    = errors.New("pairing lookback days must be positive")
+	ErrInvalidRequiredApprovals  = errors.New("required approvals must not be negative")
+	ErrInvalidSizeThresholds     = errors.New("invalid size thresholds")
 )
 
 type Service struct {
@@ -40,6 +53,61 @@ func (s *Service) AddTeam(team *api.Team) error {
 	if s.repo.ExistTeamByName(team.TeamName) {
 		return ErrTeamExists
 	}
+	if team.AssignmentStrategy == "" {
+		team.AssignmentStrategy = api.TeamAssignmentStrategyLeastLoaded
+	}
+	if !validAssignmentStrategy(team.AssignmentStrategy) {
+		return ErrInvalidAssignmentStrategy
+	}
+	if team.RequiredReviewers < 0 {
+		return ErrInvalidRequiredReviewers
+	}
+	if team.RequiredReviewers == 0 {
+		team.RequiredReviewers = service.DefaultRequiredReviewers
+	}
+	if team.RequiredApprovals < 0 {
+		return ErrInvalidRequiredApprovals
+	}
+	if !validSizeThresholds(team.SizeThresholds) {
+		return ErrInvalidSizeThresholds
+	}
+	sortSizeThresholds(team.SizeThresholds)
+	if !s.validFallbackTeams(team.TeamName, team.FallbackTeams) {
+		return ErrInvalidFallbackTeams
+	}
+	if team.WorkingHoursMode == "" {
+		team.WorkingHoursMode = api.TeamWorkingHoursModeIgnore
+	}
+	if !validWorkingHoursMode(team.WorkingHoursMode) {
+		return ErrInvalidWorkingHoursMode
+	}
+	if team.WorkingHoursLookahead < 0 {
+		return ErrInvalidLookahead
+	}
+	if team.PairingMode == "" {
+		team.PairingMode = api.TeamPairingModeIgnore
+	}
+	if !validPairingMode(team.PairingMode) {
+		return ErrInvalidPairingMode
+	}
+	if team.PairingLookbackDays < 0 {
+		return ErrInvalidPairingLookback
+	}
+	if team.PairingLookbackDays == 0 {
+		team.PairingLookbackDays = service.DefaultPairingLookbackDays
+	}
+	var err error
+	if team.MaxOpenReviews, err = openReviewLimit(team.MaxOpenReviews); err != nil {
+		return err
+	}
+	for i := range team.Members {
+		if team.Members[i].MaxOpenReviews, err = openReviewLimit(team.Members[i].MaxOpenReviews); err != nil {
+			return err
+		}
+		if seniority := team.Members[i].Seniority; seniority != "" && !seniority.Valid() {
+			return ErrInvalidSeniority
+		}
+	}
 	if err := s.repo.CreateTeam(*team); err != nil {
 		s.log.Error("AddTeam: failed to create team", "team_name", team.TeamName, "err", err)
 		return fmt.Errorf("create team: %w", err)
@@ -47,3 +115,143 @@ func (s *Service) AddTeam(team *api.Team) error {
 	s.log.Info("AddTeam: team created", "team_name", team.TeamName)
 	return nil
 }
+
+func (s *Service) UpdateTeamSettings(req api.PostTeamUpdateJSONBody) (*api.Team, error) {
+	team, err := s.GetTeamByName(req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AssignmentStrategy != nil {
+		if !validAssignmentStrategy(*req.AssignmentStrategy) {
+			return nil, ErrInvalidAssignmentStrategy
+		}
+		team.AssignmentStrategy = *req.AssignmentStrategy
+	}
+	if req.RequiredReviewers != nil {
+		if *req.RequiredReviewers <= 0 {
+			return nil, ErrInvalidRequiredReviewers
+		}
+		team.RequiredReviewers = *req.RequiredReviewers
+	}
+	if req.RequiredApprovals != nil {
+		if *req.RequiredApprovals < 0 {
+			return nil, ErrInvalidRequiredApprovals
+		}
+		team.RequiredApprovals = *req.RequiredApprovals
+	}
+	if req.SizeThresholds != nil {
+		if !validSizeThresholds(*req.SizeThresholds) {
+			return nil, ErrInvalidSizeThresholds
+		}
+		team.SizeThresholds = slices.Clone(*req.SizeThresholds)
+		sortSizeThresholds(team.SizeThresholds)
+	}
+	if req.FallbackTeams != nil {
+		if !s.validFallbackTeams(team.TeamName, *req.FallbackTeams) {
+			return nil, ErrInvalidFallbackTeams
+		}
+		team.FallbackTeams = *req.FallbackTeams
+	}
+	if req.WorkingHoursMode != nil {
+		if !validWorkingHoursMode(*req.WorkingHoursMode) {
+			return nil, ErrInvalidWorkingHoursMode
+		}
+		team.WorkingHoursMode = *req.WorkingHoursMode
+	}
+	if req.WorkingHoursLookahead != nil {
+		if *req.WorkingHoursLookahead < 0 {
+			return nil, ErrInvalidLookahead
+		}
+		team.WorkingHoursLookahead = *req.WorkingHoursLookahead
+	}
+	if req.RequireSenior != nil {
+		team.RequireSenior = *req.RequireSenior
+	}
+	if req.PairingMode != nil {
+		if !validPairingMode(*req.PairingMode) {
+			return nil, ErrInvalidPairingMode
+		}
+		team.PairingMode = *req.PairingMode
+	}
+	if req.PairingLookbackDays != nil {
+		if *req.PairingLookbackDays <= 0 {
+			return nil, ErrInvalidPairingLookback
+		}
+		team.PairingLookbackDays = *req.PairingLookbackDays
+	}
+	if req.MaxOpenReviews != nil {
+		if team.MaxOpenReviews, err = openReviewLimit(req.MaxOpenReviews); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateTeamSettings(*team); err != nil {
+		s.log.Error("UpdateTeamSettings: failed to update team", "team_name", team.TeamName, "err", err)
+		return nil, fmt.Errorf("update team: %w", err)
+	}
+	s.log.Info("UpdateTeamSettings: team updated", "team_name", team.TeamName, "strategy", team.AssignmentStrategy, "required_reviewers", team.RequiredReviewers)
+	return team, nil
+}
+
+// openReviewLimit validates a max_open_reviews value. Zero means no limit.
+func openReviewLimit(limit *int) (*int, error) {
+	switch {
+	case limit == nil || *limit == 0:
+		return nil, nil
+	case *limit < 0:
+		return nil, ErrInvalidMaxOpenReviews
+	}
+	return limit, nil
+}
+
+func validAssignmentStrategy(strategy api.TeamAssignmentStrategy) bool {
+	switch strategy {
+	case api.TeamAssignmentStrategyRandom,
+		api.TeamAssignmentStrategyRoundRobin,
+		api.TeamAssignmentStrategyLeastLoaded,
+		api.TeamAssignmentStrategyWeightedRandom:
+		return true
+	}
+	return false
+}
+
+func validWorkingHoursMode(mode api.TeamWorkingHoursMode) bool {
+	return mode == api.TeamWorkingHoursModeIgnore || mode == api.TeamWorkingHoursModePrefer
+}
+
+func validPairingMode(mode api.TeamPairingMode) bool {
+	return mode == api.TeamPairingModeIgnore || mode == api.TeamPairingModeAvoidRecent
+}
+
+// validSizeThresholds reports whether every threshold starts at a
+// non-negative size, asks for at least one reviewer and is listed only once.
+func validSizeThresholds(thresholds []api.SizeThreshold) bool {
+	seen := make(map[int]bool, len(thresholds))
+	for _, threshold := range thresholds {
+		if threshold.MinLines < 0 || threshold.Reviewers <= 0 || seen[threshold.MinLines] {
+			return false
+		}
+		seen[threshold.MinLines] = true
+	}
+	return true
+}
+
+func sortSizeThresholds(thresholds []api.SizeThreshold) {
+	slices.SortFunc(thresholds, func(a, b api.SizeThreshold) int {
+		return a.MinLines - b.MinLines
+	})
+}
+
+// validFallbackTeams reports whether every fallback team exists, differs from
+// the team itself and is listed only once.
+func (s *Service) validFallbackTeams(teamName string, fallbacks []string) bool {
+	seen := make(map[string]bool, len(fallbacks))
+	for _, fallback := range fallbacks {
+		if fallback == teamName || seen[fallback] || !s.repo.ExistTeamByName(fallback) {
+			return false
+		}
+		seen[fallback] = true
+	}
+	return true
+}
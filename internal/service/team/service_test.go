@@ -20,7 +20,11 @@ func (f *fakeTeamRepoForTest) CreateTeam(team api.Team) error {
 	f.created = append(f.created, team)
 	return f.createErr
 }
-func (f *fakeTeamRepoForTest) UpdateTeam(team api.Team) error                  { return nil }
+func (f *fakeTeamRepoForTest) UpdateTeam(team api.Team) error { return nil }
+func (f *fakeTeamRepoForTest) UpdateTeamSettings(team api.Team) error {
+	f.teams[team.TeamName] = team
+	return nil
+}
 func (f *fakeTeamRepoForTest) ExistTeamByName(name string) bool                { return f.exist }
 func (f *fakeTeamRepoForTest) FindTeamByName(name string) api.Team             { return f.teams[name] }
 func (f *fakeTeamRepoForTest) FindTeamsByUser(userID string) ([]string, error) { return nil, nil }
@@ -30,6 +34,7 @@ func (f *fakeTeamRepoForTest) FindTeamMembersByName(teamName string) ([]api.Team
 
 func TestAddTeam_Table(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	minusOne := -1
 
 	cases := []struct {
 		name    string
@@ -39,6 +44,16 @@ func TestAddTeam_Table(t *testing.T) {
 	}{
 		{"exists", &fakeTeamRepoForTest{exist: true}, api.Team{TeamName: "t1"}, ErrTeamExists},
 		{"create fails", &fakeTeamRepoForTest{exist: false, createErr: errors.New("db")}, api.Team{TeamName: "t2"}, errors.New("create team")},
+		{"unknown strategy", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t4", AssignmentStrategy: "fifo"}, ErrInvalidAssignmentStrategy},
+		{"negative reviewers", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t5", RequiredReviewers: -1}, ErrInvalidRequiredReviewers},
+		{"self fallback", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t6", FallbackTeams: []string{"t6"}}, ErrInvalidFallbackTeams},
+		{"negative member limit", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t7", Members: []api.TeamMember{{UserId: "u1", MaxOpenReviews: &minusOne}}}, ErrInvalidMaxOpenReviews},
+		{"unknown seniority", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t8", Members: []api.TeamMember{{UserId: "u1", Seniority: "principal"}}}, ErrInvalidSeniority},
+		{"unknown pairing mode", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t9", PairingMode: "never_again"}, ErrInvalidPairingMode},
+		{"negative pairing lookback", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t10", PairingLookbackDays: -1}, ErrInvalidPairingLookback},
+		{"negative approvals", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t11", RequiredApprovals: -1}, ErrInvalidRequiredApprovals},
+		{"duplicate size thresholds", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t12", SizeThresholds: []api.SizeThreshold{{MinLines: 50, Reviewers: 2}, {MinLines: 50, Reviewers: 3}}}, ErrInvalidSizeThresholds},
+		{"zero threshold reviewers", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t13", SizeThresholds: []api.SizeThreshold{{MinLines: 0, Reviewers: 0}}}, ErrInvalidSizeThresholds},
 		{"success", &fakeTeamRepoForTest{exist: false}, api.Team{TeamName: "t3"}, nil},
 	}
 
@@ -83,3 +98,46 @@ func TestGetTeamByName_Table(t *testing.T) {
 		t.Fatalf("expected error for missing team")
 	}
 }
+
+func TestUpdateTeamSettings_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	roundRobin := api.TeamAssignmentStrategyRoundRobin
+	unknown := api.TeamAssignmentStrategy("fifo")
+	zero, three := 0, 3
+
+	cases := []struct {
+		name     string
+		exist    bool
+		strategy *api.TeamAssignmentStrategy
+		required *int
+		wantErr  error
+	}{
+		{"team missing", false, &roundRobin, nil, ErrTeamNotFound},
+		{"unknown strategy", true, &unknown, nil, ErrInvalidAssignmentStrategy},
+		{"zero reviewers", true, nil, &zero, ErrInvalidRequiredReviewers},
+		{"success", true, &roundRobin, &three, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeTeamRepoForTest{exist: tc.exist, teams: map[string]api.Team{"t1": {TeamName: "t1", AssignmentStrategy: api.TeamAssignmentStrategyLeastLoaded, RequiredReviewers: 2}}}
+			svc := NewService(repo, logger)
+			team, err := svc.UpdateTeamSettings(api.PostTeamUpdateJSONBody{TeamName: "t1", AssignmentStrategy: tc.strategy, RequiredReviewers: tc.required})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if team.AssignmentStrategy != roundRobin || repo.teams["t1"].AssignmentStrategy != roundRobin {
+				t.Fatalf("expected strategy to be updated, got %q", team.AssignmentStrategy)
+			}
+			if repo.teams["t1"].RequiredReviewers != three {
+				t.Fatalf("expected required reviewers %d got %d", three, repo.teams["t1"].RequiredReviewers)
+			}
+		})
+	}
+}
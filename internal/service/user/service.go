@@ -4,12 +4,22 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
 	"github.com/V1merX/pr-reviewer-service/internal/repository"
+	"github.com/V1merX/pr-reviewer-service/internal/service/skill"
 )
 
-var ErrUserNotFound = errors.New("user not found")
+var (
+	ErrUserNotFound           = errors.New("user not found")
+	ErrInvalidSkill           = skill.ErrInvalid
+	ErrInvalidMaxOpenReviews  = errors.New("max open reviews must not be negative")
+	ErrInvalidWorkingHours    = errors.New("invalid working hours")
+	ErrInvalidSeniority       = errors.New("unknown seniority")
+	ErrInvalidDateRange       = errors.New("invalid date range")
+	ErrUnavailabilityNotFound = errors.New("unavailability not found")
+)
 
 type Service struct {
 	log            *slog.Logger
@@ -40,3 +50,191 @@ func (s *Service) SetUserStatus(userID string, status bool) (*api.User, error) {
 	s.log.Info("SetUserStatus: user status updated", "user_id", userID, "status", status)
 	return u, nil
 }
+
+// SetMaxOpenReviews sets the user's open review limit, overriding the team
+// default. A nil or zero limit removes the override. Members passed to
+// team/add without a limit keep the one set here.
+func (s *Service) SetMaxOpenReviews(userID string, limit *int) (*api.User, error) {
+	if limit != nil && *limit < 0 {
+		return nil, ErrInvalidMaxOpenReviews
+	}
+	if limit != nil && *limit == 0 {
+		limit = nil
+	}
+
+	u, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		s.log.Error("SetMaxOpenReviews: user not found", "user_id", userID, "err", err)
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.userRepository.UpdateUserMaxOpenReviews(userID, limit); err != nil {
+		s.log.Error("SetMaxOpenReviews: failed to update limit", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("update max open reviews: %w", err)
+	}
+
+	u.MaxOpenReviews = limit
+	s.log.Info("SetMaxOpenReviews: limit updated", "user_id", userID, "max_open_reviews", limit)
+	return u, nil
+}
+
+// SetSeniority sets the user's seniority level; an empty level clears it.
+func (s *Service) SetSeniority(userID string, seniority api.Seniority) (*api.User, error) {
+	if seniority != "" && !seniority.Valid() {
+		return nil, ErrInvalidSeniority
+	}
+
+	u, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		s.log.Error("SetSeniority: user not found", "user_id", userID, "err", err)
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.userRepository.UpdateUserSeniority(userID, seniority); err != nil {
+		s.log.Error("SetSeniority: failed to update seniority", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("update seniority: %w", err)
+	}
+
+	u.Seniority = seniority
+	s.log.Info("SetSeniority: seniority updated", "user_id", userID, "seniority", seniority)
+	return u, nil
+}
+
+// SetWorkingHours stores the user's time zone and daily working window.
+// Nil hours clear them, making the user count as always at work.
+func (s *Service) SetWorkingHours(userID string, hours *api.WorkingHours) (*api.User, error) {
+	if hours != nil {
+		if err := validateWorkingHours(*hours); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		s.log.Error("SetWorkingHours: user not found", "user_id", userID, "err", err)
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.userRepository.UpdateUserWorkingHours(userID, hours); err != nil {
+		s.log.Error("SetWorkingHours: failed to update working hours", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("update working hours: %w", err)
+	}
+
+	u.WorkingHours = hours
+	s.log.Info("SetWorkingHours: working hours updated", "user_id", userID, "working_hours", hours)
+	return u, nil
+}
+
+func validateWorkingHours(hours api.WorkingHours) error {
+	if hours.Timezone == "" {
+		return ErrInvalidWorkingHours
+	}
+	if _, err := time.LoadLocation(hours.Timezone); err != nil {
+		return ErrInvalidWorkingHours
+	}
+	start, err := time.Parse("15:04", hours.Start)
+	if err != nil {
+		return ErrInvalidWorkingHours
+	}
+	end, err := time.Parse("15:04", hours.End)
+	if err != nil || start.Equal(end) {
+		return ErrInvalidWorkingHours
+	}
+	return nil
+}
+
+func (s *Service) SetUserSkills(userID string, skills []string) (*api.User, error) {
+	normalized, err := skill.Normalize(skills)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		s.log.Error("SetUserSkills: user not found", "user_id", userID, "err", err)
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.userRepository.SetUserSkills(userID, normalized); err != nil {
+		s.log.Error("SetUserSkills: failed to store skills", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set user skills: %w", err)
+	}
+
+	u.Skills = normalized
+	s.log.Info("SetUserSkills: user skills updated", "user_id", userID, "skills", normalized)
+	return u, nil
+}
+
+func (s *Service) AddUnavailability(req api.PostUsersAddUnavailabilityJSONBody) (*api.Unavailability, error) {
+	if err := validateDateRange(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepository.FindUserByID(req.UserId); err != nil {
+		s.log.Error("AddUnavailability: user not found", "user_id", req.UserId, "err", err)
+		return nil, ErrUserNotFound
+	}
+
+	u := api.Unavailability{UserId: req.UserId, StartDate: req.StartDate, EndDate: req.EndDate, Reason: req.Reason}
+	id, err := s.userRepository.CreateUnavailability(u)
+	if err != nil {
+		s.log.Error("AddUnavailability: failed to store range", "user_id", req.UserId, "err", err)
+		return nil, fmt.Errorf("create unavailability: %w", err)
+	}
+	u.Id = id
+	s.log.Info("AddUnavailability: range added", "user_id", u.UserId, "id", id, "start", u.StartDate, "end", u.EndDate)
+	return &u, nil
+}
+
+func (s *Service) GetUnavailability(userID string) ([]api.Unavailability, error) {
+	if _, err := s.userRepository.FindUserByID(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	return s.userRepository.FindUnavailabilityByUser(userID)
+}
+
+func (s *Service) UpdateUnavailability(req api.PostUsersUpdateUnavailabilityJSONBody) (*api.Unavailability, error) {
+	if err := validateDateRange(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	u, err := s.userRepository.FindUnavailabilityByID(req.Id)
+	if err != nil {
+		return nil, ErrUnavailabilityNotFound
+	}
+
+	u.StartDate, u.EndDate, u.Reason = req.StartDate, req.EndDate, req.Reason
+	if err := s.userRepository.UpdateUnavailability(*u); err != nil {
+		s.log.Error("UpdateUnavailability: failed to update range", "id", req.Id, "err", err)
+		return nil, fmt.Errorf("update unavailability: %w", err)
+	}
+	s.log.Info("UpdateUnavailability: range updated", "id", u.Id, "start", u.StartDate, "end", u.EndDate)
+	return u, nil
+}
+
+func (s *Service) DeleteUnavailability(id int64) error {
+	if _, err := s.userRepository.FindUnavailabilityByID(id); err != nil {
+		return ErrUnavailabilityNotFound
+	}
+	if err := s.userRepository.DeleteUnavailability(id); err != nil {
+		s.log.Error("DeleteUnavailability: failed to delete range", "id", id, "err", err)
+		return fmt.Errorf("delete unavailability: %w", err)
+	}
+	s.log.Info("DeleteUnavailability: range deleted", "id", id)
+	return nil
+}
+
+// validateDateRange checks that both dates are YYYY-MM-DD and that the range
+// does not end before it starts. Both days are inclusive.
+func validateDateRange(start, end string) error {
+	startDay, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return ErrInvalidDateRange
+	}
+	endDay, err := time.Parse(time.DateOnly, end)
+	if err != nil {
+		return ErrInvalidDateRange
+	}
+	if endDay.Before(startDay) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
 	"testing"
 
 	"github.com/V1merX/pr-reviewer-service/internal/api"
@@ -11,6 +12,8 @@ import (
 
 type fakeUserRepoForTest struct {
 	users        map[string]api.User
+	skills       map[string][]string
+	ranges       map[int64]api.Unavailability
 	updateErr    error
 	updatedCalls []struct {
 		UserID string
@@ -33,7 +36,62 @@ func (f *fakeUserRepoForTest) UpdateUserStatus(userID string, status bool) error
 	}{UserID: userID, Status: status})
 	return f.updateErr
 }
+func (f *fakeUserRepoForTest) UpdateUserMaxOpenReviews(userID string, limit *int) error {
+	u := f.users[userID]
+	u.MaxOpenReviews = limit
+	f.users[userID] = u
+	return f.updateErr
+}
+func (f *fakeUserRepoForTest) UpdateUserSeniority(userID string, seniority api.Seniority) error {
+	u := f.users[userID]
+	u.Seniority = seniority
+	f.users[userID] = u
+	return f.updateErr
+}
 func (f *fakeUserRepoForTest) GetAllUsers() ([]api.User, error) { return nil, nil }
+func (f *fakeUserRepoForTest) CreateUnavailability(u api.Unavailability) (int64, error) {
+	id := int64(len(f.ranges) + 1)
+	u.Id = id
+	f.ranges[id] = u
+	return id, nil
+}
+func (f *fakeUserRepoForTest) FindUnavailabilityByID(id int64) (*api.Unavailability, error) {
+	u, ok := f.ranges[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &u, nil
+}
+func (f *fakeUserRepoForTest) FindUnavailabilityByUser(userID string) ([]api.Unavailability, error) {
+	return nil, nil
+}
+func (f *fakeUserRepoForTest) UpdateUnavailability(u api.Unavailability) error {
+	f.ranges[u.Id] = u
+	return nil
+}
+func (f *fakeUserRepoForTest) DeleteUnavailability(id int64) error {
+	delete(f.ranges, id)
+	return nil
+}
+func (f *fakeUserRepoForTest) UpdateUserWorkingHours(userID string, hours *api.WorkingHours) error {
+	u := f.users[userID]
+	u.WorkingHours = hours
+	f.users[userID] = u
+	return nil
+}
+func (f *fakeUserRepoForTest) FindWorkingHoursByUsers(userIDs []string) (map[string]api.WorkingHours, error) {
+	return nil, nil
+}
+func (f *fakeUserRepoForTest) FindUnavailableUserIDs(day string) (map[string]bool, error) {
+	return nil, nil
+}
+func (f *fakeUserRepoForTest) SetUserSkills(userID string, skills []string) error {
+	f.skills[userID] = skills
+	return nil
+}
+func (f *fakeUserRepoForTest) FindSkillsByUsers(userIDs []string) (map[string][]string, error) {
+	return f.skills, nil
+}
 
 func TestSetUserStatus_Table(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -84,3 +142,213 @@ func TestSetUserStatus_Table(t *testing.T) {
 	}
 	_ = logger
 }
+
+func TestSetUserSkills_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name    string
+		userID  string
+		skills  []string
+		want    []string
+		wantErr error
+	}{
+		{"user not found", "u2", []string{"go"}, nil, ErrUserNotFound},
+		{"empty tag", "u1", []string{"go", " "}, nil, ErrInvalidSkill},
+		{"tag with space", "u1", []string{"front end"}, nil, ErrInvalidSkill},
+		{"normalized", "u1", []string{" Go", "SQL", "go"}, []string{"go", "sql"}, nil},
+		{"cleared", "u1", []string{}, []string{}, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeUserRepoForTest{users: map[string]api.User{"u1": {UserId: "u1"}}, skills: map[string][]string{}}
+			svc := NewService(logger, repo)
+			u, err := svc.SetUserSkills(tc.userID, tc.skills)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(u.Skills, tc.want) || !slices.Equal(repo.skills[tc.userID], tc.want) {
+				t.Fatalf("want skills %v got %v (stored %v)", tc.want, u.Skills, repo.skills[tc.userID])
+			}
+		})
+	}
+}
+
+func TestSetMaxOpenReviews_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	minusOne, zero, two, three := -1, 0, 2, 3
+
+	cases := []struct {
+		name    string
+		userID  string
+		limit   *int
+		want    *int
+		wantErr error
+	}{
+		{"user not found", "u2", &three, nil, ErrUserNotFound},
+		{"negative limit", "u1", &minusOne, nil, ErrInvalidMaxOpenReviews},
+		{"zero clears the limit", "u1", &zero, nil, nil},
+		{"set", "u1", &three, &three, nil},
+		{"clear", "u1", nil, nil, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeUserRepoForTest{users: map[string]api.User{"u1": {UserId: "u1", MaxOpenReviews: &two}}}
+			svc := NewService(logger, repo)
+			u, err := svc.SetMaxOpenReviews(tc.userID, tc.limit)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.MaxOpenReviews != tc.want || repo.users["u1"].MaxOpenReviews != tc.want {
+				t.Fatalf("expected limit %v got %v", tc.want, u.MaxOpenReviews)
+			}
+		})
+	}
+}
+
+func TestAddUnavailability_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name    string
+		req     api.PostUsersAddUnavailabilityJSONBody
+		wantErr error
+	}{
+		{"user not found", api.PostUsersAddUnavailabilityJSONBody{UserId: "u2", StartDate: "2025-03-01", EndDate: "2025-03-05"}, ErrUserNotFound},
+		{"bad date", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", StartDate: "03/01/2025", EndDate: "2025-03-05"}, ErrInvalidDateRange},
+		{"ends before start", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", StartDate: "2025-03-05", EndDate: "2025-03-01"}, ErrInvalidDateRange},
+		{"single day", api.PostUsersAddUnavailabilityJSONBody{UserId: "u1", StartDate: "2025-03-01", EndDate: "2025-03-01", Reason: "dentist"}, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeUserRepoForTest{users: map[string]api.User{"u1": {UserId: "u1"}}, ranges: map[int64]api.Unavailability{}}
+			svc := NewService(logger, repo)
+			u, err := svc.AddUnavailability(tc.req)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.Id == 0 || repo.ranges[u.Id].Reason != tc.req.Reason {
+				t.Fatalf("expected range to be stored, got %+v", repo.ranges)
+			}
+		})
+	}
+}
+
+func TestUpdateAndDeleteUnavailability(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &fakeUserRepoForTest{ranges: map[int64]api.Unavailability{1: {Id: 1, UserId: "u1", StartDate: "2025-03-01", EndDate: "2025-03-05"}}}
+	svc := NewService(logger, repo)
+
+	u, err := svc.UpdateUnavailability(api.PostUsersUpdateUnavailabilityJSONBody{Id: 1, StartDate: "2025-03-01", EndDate: "2025-03-20", Reason: "extended"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.UserId != "u1" || repo.ranges[1].EndDate != "2025-03-20" {
+		t.Fatalf("expected range to be updated, got %+v", repo.ranges[1])
+	}
+
+	if _, err := svc.UpdateUnavailability(api.PostUsersUpdateUnavailabilityJSONBody{Id: 7, StartDate: "2025-03-01", EndDate: "2025-03-02"}); !errors.Is(err, ErrUnavailabilityNotFound) {
+		t.Fatalf("want %v got %v", ErrUnavailabilityNotFound, err)
+	}
+	if err := svc.DeleteUnavailability(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteUnavailability(1); !errors.Is(err, ErrUnavailabilityNotFound) {
+		t.Fatalf("want %v got %v", ErrUnavailabilityNotFound, err)
+	}
+}
+
+func TestSetWorkingHours_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name    string
+		userID  string
+		hours   *api.WorkingHours
+		wantErr error
+	}{
+		{"user not found", "u2", &api.WorkingHours{Timezone: "UTC", Start: "09:00", End: "17:00"}, ErrUserNotFound},
+		{"unknown timezone", "u1", &api.WorkingHours{Timezone: "Mars/Olympus", Start: "09:00", End: "17:00"}, ErrInvalidWorkingHours},
+		{"bad clock", "u1", &api.WorkingHours{Timezone: "UTC", Start: "9am", End: "17:00"}, ErrInvalidWorkingHours},
+		{"empty window", "u1", &api.WorkingHours{Timezone: "UTC", Start: "09:00", End: "09:00"}, ErrInvalidWorkingHours},
+		{"night shift", "u1", &api.WorkingHours{Timezone: "Asia/Tokyo", Start: "22:00", End: "06:00"}, nil},
+		{"clear", "u1", nil, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeUserRepoForTest{users: map[string]api.User{"u1": {UserId: "u1"}}}
+			svc := NewService(logger, repo)
+			u, err := svc.SetWorkingHours(tc.userID, tc.hours)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.WorkingHours != tc.hours || repo.users["u1"].WorkingHours != tc.hours {
+				t.Fatalf("expected working hours %v got %v", tc.hours, u.WorkingHours)
+			}
+		})
+	}
+}
+
+func TestSetSeniority_Table(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name      string
+		userID    string
+		seniority api.Seniority
+		wantErr   error
+	}{
+		{"user not found", "u2", api.SenioritySenior, ErrUserNotFound},
+		{"unknown level", "u1", "principal", ErrInvalidSeniority},
+		{"lead", "u1", api.SeniorityLead, nil},
+		{"clear", "u1", "", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeUserRepoForTest{users: map[string]api.User{"u1": {UserId: "u1", Seniority: api.SeniorityMid}}}
+			svc := NewService(logger, repo)
+			u, err := svc.SetSeniority(tc.userID, tc.seniority)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.Seniority != tc.seniority || repo.users["u1"].Seniority != tc.seniority {
+				t.Fatalf("expected seniority %q got %q", tc.seniority, u.Seniority)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS assignment_strategy TEXT NOT NULL DEFAULT 'least_loaded'
+  CHECK (assignment_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted_random'));
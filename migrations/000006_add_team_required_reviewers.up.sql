@@ -0,0 +1,3 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS required_reviewers INT NOT NULL DEFAULT 2
+  CHECK (required_reviewers > 0);
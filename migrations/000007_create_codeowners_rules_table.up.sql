@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS codeowners_rules (
+  repository TEXT NOT NULL,
+  position INT NOT NULL,
+  pattern TEXT NOT NULL,
+  owners TEXT[] NOT NULL DEFAULT '{}',
+  PRIMARY KEY(repository, position)
+);
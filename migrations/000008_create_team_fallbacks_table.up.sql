@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+  team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+  fallback_team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+  position INT NOT NULL,
+  PRIMARY KEY(team_name, fallback_team_name),
+  CHECK (team_name <> fallback_team_name)
+);
@@ -0,0 +1 @@
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS team_name;
@@ -0,0 +1 @@
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS team_name TEXT REFERENCES teams(team_name);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_unavailability (
+  id BIGSERIAL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  starts_on DATE NOT NULL,
+  ends_on DATE NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  CHECK (ends_on >= starts_on)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_id ON user_unavailability(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_range ON user_unavailability(starts_on, ends_on);
@@ -0,0 +1,5 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS working_hours_lookahead;
+ALTER TABLE teams DROP COLUMN IF EXISTS working_hours_mode;
+ALTER TABLE users DROP COLUMN IF EXISTS work_end;
+ALTER TABLE users DROP COLUMN IF EXISTS work_start;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
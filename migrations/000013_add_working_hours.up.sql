@@ -0,0 +1,10 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS timezone TEXT,
+  ADD COLUMN IF NOT EXISTS work_start TEXT,
+  ADD COLUMN IF NOT EXISTS work_end TEXT;
+
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS working_hours_mode TEXT NOT NULL DEFAULT 'ignore'
+  CHECK (working_hours_mode IN ('ignore', 'prefer')),
+  ADD COLUMN IF NOT EXISTS working_hours_lookahead INT NOT NULL DEFAULT 0
+  CHECK (working_hours_lookahead >= 0);
@@ -0,0 +1,2 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS require_senior;
+ALTER TABLE users DROP COLUMN IF EXISTS seniority;
@@ -0,0 +1,6 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS seniority TEXT
+  CHECK (seniority IN ('junior', 'mid', 'senior', 'lead'));
+
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS require_senior BOOLEAN NOT NULL DEFAULT false;
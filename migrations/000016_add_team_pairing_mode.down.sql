@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_author_created;
+ALTER TABLE teams DROP COLUMN IF EXISTS pairing_lookback_days;
+ALTER TABLE teams DROP COLUMN IF EXISTS pairing_mode;
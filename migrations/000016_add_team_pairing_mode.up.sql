@@ -0,0 +1,7 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS pairing_mode TEXT NOT NULL DEFAULT 'ignore'
+  CHECK (pairing_mode IN ('ignore', 'avoid_recent')),
+  ADD COLUMN IF NOT EXISTS pairing_lookback_days INT NOT NULL DEFAULT 30
+  CHECK (pairing_lookback_days > 0);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_created ON pull_requests (author_id, created_at);
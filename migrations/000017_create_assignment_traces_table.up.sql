@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS assignment_traces (
+  id BIGSERIAL PRIMARY KEY,
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  action TEXT NOT NULL CHECK (action IN ('create', 'reassign')),
+  trace JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_traces_pull_request_id ON assignment_traces(pull_request_id);
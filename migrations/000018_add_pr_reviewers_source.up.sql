@@ -0,0 +1,3 @@
+ALTER TABLE pr_reviewers
+  ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'auto'
+  CHECK (source IN ('auto', 'requested'));
@@ -0,0 +1,9 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+  ADD CONSTRAINT pull_requests_status_check
+  CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+
+ALTER TABLE assignment_traces DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+ALTER TABLE assignment_traces
+  ADD CONSTRAINT assignment_traces_action_check
+  CHECK (action IN ('create', 'reassign', 'mark_ready'));
@@ -0,0 +1 @@
+-- Nothing to undo: 000019 owns the mark_ready trace action.
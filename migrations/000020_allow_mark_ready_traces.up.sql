@@ -0,0 +1,2 @@
+-- The mark_ready trace action is allowed by 000019. This version is kept so
+-- that the numbering has no gap and databases already at it stay valid.
@@ -0,0 +1,2 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS required_approvals;
+DROP TABLE IF EXISTS pr_reviews;
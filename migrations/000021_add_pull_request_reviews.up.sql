@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS pr_reviews (
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(user_id),
+  verdict TEXT NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+  submitted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY(pull_request_id, user_id)
+);
+
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS required_approvals INT NOT NULL DEFAULT 0
+  CHECK (required_approvals >= 0);
@@ -0,0 +1,12 @@
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'pr_reviews' AND column_name = 'round') THEN
+    DELETE FROM pr_reviews r USING pr_reviews newer
+      WHERE newer.pull_request_id = r.pull_request_id AND newer.user_id = r.user_id AND newer.round > r.round;
+    ALTER TABLE pr_reviews DROP CONSTRAINT IF EXISTS pr_reviews_pkey;
+    ALTER TABLE pr_reviews ADD PRIMARY KEY (pull_request_id, user_id);
+  END IF;
+END $$;
+ALTER TABLE pr_reviews DROP COLUMN IF EXISTS round;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS review_round;
@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+  ADD COLUMN IF NOT EXISTS review_round INT NOT NULL DEFAULT 1
+  CHECK (review_round > 0);
+
+ALTER TABLE pr_reviews
+  ADD COLUMN IF NOT EXISTS round INT NOT NULL DEFAULT 1;
+ALTER TABLE pr_reviews DROP CONSTRAINT IF EXISTS pr_reviews_pkey;
+ALTER TABLE pr_reviews ADD PRIMARY KEY (pull_request_id, round, user_id);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_pull_requests_labels;
+DROP INDEX IF EXISTS idx_pull_requests_repository;
+ALTER TABLE pull_requests
+  DROP COLUMN IF EXISTS files_changed,
+  DROP COLUMN IF EXISTS lines_removed,
+  DROP COLUMN IF EXISTS lines_added,
+  DROP COLUMN IF EXISTS labels,
+  DROP COLUMN IF EXISTS target_branch,
+  DROP COLUMN IF EXISTS source_branch,
+  DROP COLUMN IF EXISTS url,
+  DROP COLUMN IF EXISTS repository;
@@ -0,0 +1,12 @@
+ALTER TABLE pull_requests
+  ADD COLUMN IF NOT EXISTS repository TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS url TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS source_branch TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS target_branch TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS lines_added INT NOT NULL DEFAULT 0 CHECK (lines_added >= 0),
+  ADD COLUMN IF NOT EXISTS lines_removed INT NOT NULL DEFAULT 0 CHECK (lines_removed >= 0),
+  ADD COLUMN IF NOT EXISTS files_changed INT NOT NULL DEFAULT 0 CHECK (files_changed >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_repository ON pull_requests (repository);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_labels ON pull_requests USING GIN (labels);
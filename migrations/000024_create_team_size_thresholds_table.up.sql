@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS team_size_thresholds (
+  team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+  min_lines INT NOT NULL CHECK (min_lines >= 0),
+  reviewers INT NOT NULL CHECK (reviewers > 0),
+  PRIMARY KEY(team_name, min_lines)
+);
+
+ALTER TABLE assignment_traces DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+ALTER TABLE assignment_traces
+  ADD CONSTRAINT assignment_traces_action_check
+  CHECK (action IN ('create', 'reassign', 'mark_ready', 'top_up'));
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_id ON pull_requests (created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged_id ON pull_requests ((COALESCE(merged_at, '-infinity')), pull_request_id);
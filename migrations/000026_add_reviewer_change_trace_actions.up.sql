@@ -0,0 +1,4 @@
+ALTER TABLE assignment_traces DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+ALTER TABLE assignment_traces
+  ADD CONSTRAINT assignment_traces_action_check
+  CHECK (action IN ('create', 'reassign', 'mark_ready', 'top_up', 'add_reviewer', 'remove_reviewer', 'rebalance'));